@@ -0,0 +1,192 @@
+// Copyright 2026 Conductor OSS
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+
+package markitdown
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// AssetWriter stores files extracted from documents, such as embedded images,
+// so that the Markdown links to them instead of inlining them as data URIs.
+// Set one with WithAssetWriter.
+type AssetWriter interface {
+	// WriteAsset stores data and returns the link to use for it in the
+	// Markdown. name is derived from a hash of data and has an extension
+	// matching mediaType, so identical content always has the same name.
+	WriteAsset(ctx context.Context, name, mediaType string, data []byte) (link string, err error)
+}
+
+// DirAssetWriter is an AssetWriter that stores assets as files in Dir, which
+// is created if needed. Assets are linked as LinkPrefix + "/" + name, or by
+// name alone when LinkPrefix is empty; set LinkPrefix to the path of Dir
+// relative to the Markdown output. Files that already exist are not
+// rewritten, so assets shared by several documents are stored once.
+type DirAssetWriter struct {
+	Dir        string
+	LinkPrefix string
+}
+
+// WriteAsset implements AssetWriter.
+func (w *DirAssetWriter) WriteAsset(ctx context.Context, name, mediaType string, data []byte) (string, error) {
+	if name == "" || name != filepath.Base(name) {
+		return "", fmt.Errorf("invalid asset name %q", name)
+	}
+	if err := os.MkdirAll(w.Dir, 0o755); err != nil {
+		return "", fmt.Errorf("create asset directory: %w", err)
+	}
+	dst := filepath.Join(w.Dir, name)
+	if _, err := os.Stat(dst); errors.Is(err, fs.ErrNotExist) {
+		// Write to a temporary file first so that concurrent conversions
+		// never see a partial asset.
+		tmp, err := os.CreateTemp(w.Dir, ".asset-*")
+		if err != nil {
+			return "", err
+		}
+		_, werr := tmp.Write(data)
+		cerr := tmp.Close()
+		if err := errors.Join(werr, cerr); err != nil {
+			os.Remove(tmp.Name())
+			return "", err
+		}
+		if err := os.Rename(tmp.Name(), dst); err != nil {
+			os.Remove(tmp.Name())
+			return "", err
+		}
+	} else if err != nil {
+		return "", err
+	}
+	if w.LinkPrefix == "" {
+		return name, nil
+	}
+	return strings.TrimSuffix(w.LinkPrefix, "/") + "/" + name, nil
+}
+
+// imageTypes maps image file extensions to media types. The first extension
+// listed for a media type is the one used for asset names.
+var imageTypes = []struct{ ext, mediaType string }{
+	{".png", "image/png"},
+	{".jpg", "image/jpeg"},
+	{".jpeg", "image/jpeg"},
+	{".gif", "image/gif"},
+	{".bmp", "image/bmp"},
+	{".svg", "image/svg+xml"},
+	{".webp", "image/webp"},
+	{".tif", "image/tiff"},
+	{".tiff", "image/tiff"},
+	{".emf", "image/x-emf"},
+	{".wmf", "image/x-wmf"},
+}
+
+// imageMediaType returns the media type of an image file name, or "" if the
+// extension is not a known image type.
+func imageMediaType(name string) string {
+	ext := strings.ToLower(path.Ext(name))
+	for _, t := range imageTypes {
+		if t.ext == ext {
+			return t.mediaType
+		}
+	}
+	return ""
+}
+
+// assetExtension returns the file extension for an asset's media type.
+func assetExtension(mediaType string) string {
+	for _, t := range imageTypes {
+		if t.mediaType == mediaType {
+			return t.ext
+		}
+	}
+	return ".bin"
+}
+
+// saveAsset stores data with the asset writer and returns its link. Content
+// already stored during the current conversion is not written again.
+func (m *MarkItDown) saveAsset(ctx context.Context, mediaType string, data []byte) (string, error) {
+	st := stateFrom(ctx)
+	sum := sha256.Sum256(data)
+	if link, ok := st.assets[sum]; ok {
+		return link, nil
+	}
+	name := hex.EncodeToString(sum[:16]) + assetExtension(mediaType)
+	link, err := m.assetWriter.WriteAsset(ctx, name, mediaType, data)
+	if err != nil {
+		return "", fmt.Errorf("write asset %s: %w", name, err)
+	}
+	st.assets[sum] = link
+	return link, nil
+}
+
+var (
+	reMarkdownImage = regexp.MustCompile(`!\[((?:[^\]\\]|\\.)*)\]\(([^)\s]+)`)
+	reImageDataURI  = regexp.MustCompile(`^data:(image/[a-zA-Z0-9.+-]+);base64,([A-Za-z0-9+/=]+)$`)
+)
+
+// processImages passes the images linked from md to the image describer and
+// the asset writer, if configured: placeholder alt text is replaced by a
+// description and links point at the stored copies. Data URIs are decoded;
+// other link targets are passed to open, which may be nil, and are left
+// unchanged when open does not find them.
+func (m *MarkItDown) processImages(ctx context.Context, md string, open func(target string) ([]byte, bool)) (string, error) {
+	if m.assetWriter == nil && m.imageDescriber == nil {
+		return md, nil
+	}
+	var firstErr error
+	md = reMarkdownImage.ReplaceAllStringFunc(md, func(match string) string {
+		if firstErr != nil {
+			return match
+		}
+		sub := reMarkdownImage.FindStringSubmatch(match)
+		alt, target := sub[1], sub[2]
+		var data []byte
+		var name, mediaType string
+		if d := reImageDataURI.FindStringSubmatch(target); d != nil {
+			decoded, err := base64.StdEncoding.DecodeString(d[2])
+			if err != nil {
+				return match
+			}
+			data, mediaType = decoded, d[1]
+		} else if open != nil {
+			found, ok := open(target)
+			if !ok {
+				return match
+			}
+			data, name, mediaType = found, target, imageMediaType(target)
+		} else {
+			return match
+		}
+
+		alt, err := m.describeImage(ctx, alt, name, mediaType, data)
+		if err != nil {
+			firstErr = err
+			return match
+		}
+		if m.assetWriter != nil {
+			if target, err = m.saveAsset(ctx, mediaType, data); err != nil {
+				firstErr = err
+				return match
+			}
+		}
+		return "![" + alt + "](" + target
+	})
+	return md, firstErr
+}
@@ -0,0 +1,355 @@
+// Copyright 2026 Conductor OSS
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+
+// Package chunk splits converted markdown into chunks for retrieval pipelines.
+//
+// Chunks follow the heading hierarchy: every heading starts a new chunk, and
+// sections larger than the target size are split at block boundaries. Fenced
+// code blocks are never split. Tables are split between rows only, and every
+// part repeats the table's header. Each chunk records its heading breadcrumb
+// and, for results with segments, the page, slide, sheet, chapter or archive
+// entry it came from.
+package chunk
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	markitdown "github.com/conductor-oss/markitdown"
+)
+
+// DefaultMaxChars is the target chunk size used when Options sets no limit.
+const DefaultMaxChars = 2000
+
+// charsPerToken approximates the tokenizer ratio for English text.
+const charsPerToken = 4
+
+// Options configures chunking.
+type Options struct {
+	// MaxChars is the target chunk size in characters.
+	MaxChars int
+	// MaxTokens, when set, is the target chunk size in approximate tokens
+	// (about four characters each) and takes precedence over MaxChars.
+	MaxTokens int
+}
+
+func (o Options) limit() int {
+	switch {
+	case o.MaxTokens > 0:
+		return o.MaxTokens * charsPerToken
+	case o.MaxChars > 0:
+		return o.MaxChars
+	default:
+		return DefaultMaxChars
+	}
+}
+
+// Locator identifies where in the source document a chunk came from. It is
+// the zero value when the result has no segments.
+type Locator struct {
+	Kind   markitdown.SegmentKind
+	Number int
+	Name   string
+}
+
+// Chunk is a piece of converted markdown.
+type Chunk struct {
+	// Index is the chunk's 0-based position in the document.
+	Index int
+	// Markdown is the chunk content.
+	Markdown string
+	// Headings is the breadcrumb of enclosing headings, outermost first.
+	Headings []string
+	// Source locates the chunk in the original document.
+	Source Locator
+}
+
+// Split chunks a markdown string.
+func Split(markdown string, opts Options) []Chunk {
+	s := &splitter{max: opts.limit()}
+	s.split(markdown, Locator{})
+	s.flush()
+	return s.out
+}
+
+// FromResult chunks a conversion result. Results with segments are chunked
+// segment by segment so that every chunk has a single source locator; the
+// heading breadcrumb carries over page boundaries but restarts for slides,
+// sheets, chapters and archive entries.
+func FromResult(result *markitdown.DocumentConverterResult, opts Options) []Chunk {
+	if len(result.Segments) == 0 {
+		return Split(result.Markdown, opts)
+	}
+	s := &splitter{max: opts.limit()}
+	for _, seg := range result.Segments {
+		if seg.Kind != markitdown.SegmentPage {
+			s.headings, s.levels = nil, nil
+		}
+		s.split(seg.Markdown, Locator{Kind: seg.Kind, Number: seg.Number, Name: seg.Name})
+		s.flush()
+	}
+	return s.out
+}
+
+type blockKind int
+
+const (
+	blockText blockKind = iota
+	blockHeading
+	blockCode
+	blockTable
+)
+
+// block is a top-level markdown element.
+type block struct {
+	kind  blockKind
+	lines []string
+	level int    // heading level
+	title string // heading text
+}
+
+var (
+	reHeading    = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	reFence      = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+	reTableDelim = regexp.MustCompile(`^\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?$`)
+)
+
+// parseBlocks splits markdown into headings, fenced code blocks, tables and
+// blank-line separated text blocks.
+func parseBlocks(markdown string) []block {
+	lines := strings.Split(markdown, "\n")
+	var blocks []block
+	var text []string
+	flushText := func() {
+		if len(text) > 0 {
+			blocks = append(blocks, block{kind: blockText, lines: text})
+			text = nil
+		}
+	}
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case strings.TrimSpace(line) == "":
+			flushText()
+			i++
+
+		case reFence.MatchString(line):
+			flushText()
+			fence := strings.TrimSpace(reFence.FindStringSubmatch(line)[1])
+			end := i + 1
+			for end < len(lines) && !closesFence(lines[end], fence) {
+				end++
+			}
+			if end < len(lines) {
+				end++ // include the closing fence
+			}
+			blocks = append(blocks, block{kind: blockCode, lines: lines[i:end]})
+			i = end
+
+		case reHeading.MatchString(line):
+			flushText()
+			m := reHeading.FindStringSubmatch(line)
+			blocks = append(blocks, block{kind: blockHeading, lines: []string{line}, level: len(m[1]), title: strings.TrimSpace(m[2])})
+			i++
+
+		case isTableStart(lines, i):
+			flushText()
+			end := i + 2
+			for end < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[end]), "|") {
+				end++
+			}
+			blocks = append(blocks, block{kind: blockTable, lines: lines[i:end]})
+			i = end
+
+		default:
+			text = append(text, line)
+			i++
+		}
+	}
+	flushText()
+	return blocks
+}
+
+func closesFence(line, fence string) bool {
+	trimmed := strings.TrimSpace(line)
+	return len(trimmed) >= len(fence) && strings.Trim(trimmed, fence[:1]) == ""
+}
+
+func isTableStart(lines []string, i int) bool {
+	return i+1 < len(lines) &&
+		strings.HasPrefix(strings.TrimSpace(lines[i]), "|") &&
+		reTableDelim.MatchString(strings.TrimSpace(lines[i+1]))
+}
+
+// splitter accumulates blocks into chunks.
+type splitter struct {
+	max int
+
+	headings []string // breadcrumb titles
+	levels   []int    // heading level of each breadcrumb entry
+
+	parts      []string // pieces of the current chunk
+	size       int      // characters in parts, including separators
+	hasContent bool     // parts holds more than headings
+	loc        Locator
+
+	out []Chunk
+}
+
+func (s *splitter) split(markdown string, loc Locator) {
+	s.loc = loc
+	for _, b := range parseBlocks(markdown) {
+		if b.kind == blockHeading {
+			if s.hasContent {
+				s.flush()
+			}
+			for len(s.levels) > 0 && s.levels[len(s.levels)-1] >= b.level {
+				s.levels = s.levels[:len(s.levels)-1]
+				s.headings = s.headings[:len(s.headings)-1]
+			}
+			if b.title != "" {
+				s.levels = append(s.levels, b.level)
+				s.headings = append(s.headings, b.title)
+			}
+			s.add(b.lines[0])
+			continue
+		}
+
+		for _, piece := range s.pieces(b) {
+			// A chunk holding only headings keeps them with the content that
+			// follows, even if that overshoots the target size.
+			if s.hasContent && s.size+2+runeCount(piece) > s.max {
+				s.flush()
+			}
+			s.add(piece)
+			s.hasContent = true
+		}
+	}
+}
+
+func (s *splitter) add(piece string) {
+	if len(s.parts) > 0 {
+		s.size += 2
+	}
+	s.parts = append(s.parts, piece)
+	s.size += runeCount(piece)
+}
+
+// flush emits the current chunk, if any.
+func (s *splitter) flush() {
+	if len(s.parts) > 0 {
+		s.out = append(s.out, Chunk{
+			Index:    len(s.out),
+			Markdown: strings.Join(s.parts, "\n\n"),
+			Headings: append([]string(nil), s.headings...),
+			Source:   s.loc,
+		})
+	}
+	s.parts, s.size, s.hasContent = nil, 0, false
+}
+
+// pieces returns a block's text, split into parts no larger than the target
+// size where the block kind allows it.
+func (s *splitter) pieces(b block) []string {
+	text := strings.Join(b.lines, "\n")
+	if runeCount(text) <= s.max {
+		return []string{text}
+	}
+	switch b.kind {
+	case blockCode:
+		return []string{text}
+	case blockTable:
+		return splitTable(b.lines, s.max)
+	default:
+		return splitText(b.lines, s.max)
+	}
+}
+
+// splitTable splits a table between rows, repeating the header and
+// delimiter rows in every part.
+func splitTable(lines []string, max int) []string {
+	header := strings.Join(lines[:2], "\n")
+	var parts []string
+	cur := header
+	rows := 0
+	for _, row := range lines[2:] {
+		if rows > 0 && runeCount(cur)+1+runeCount(row) > max {
+			parts = append(parts, cur)
+			cur, rows = header, 0
+		}
+		cur += "\n" + row
+		rows++
+	}
+	return append(parts, cur)
+}
+
+// splitText packs lines into parts, breaking overlong lines between words
+// and overlong words between characters.
+func splitText(lines []string, max int) []string {
+	// Each unit records the separator that joins it to the previous unit.
+	type unit struct{ sep, text string }
+	var units []unit
+	for _, line := range lines {
+		if runeCount(line) <= max {
+			units = append(units, unit{"\n", line})
+			continue
+		}
+		sep := "\n"
+		for _, word := range strings.Fields(line) {
+			for runeCount(word) > max {
+				cut := byteOffset(word, max)
+				units = append(units, unit{sep, word[:cut]})
+				word, sep = word[cut:], ""
+			}
+			units = append(units, unit{sep, word})
+			sep = " "
+		}
+	}
+
+	var parts []string
+	var cur strings.Builder
+	curLen := 0
+	for _, u := range units {
+		n := runeCount(u.text)
+		if curLen > 0 && curLen+len(u.sep)+n > max {
+			parts = append(parts, cur.String())
+			cur.Reset()
+			curLen = 0
+		}
+		if curLen > 0 {
+			cur.WriteString(u.sep)
+			curLen += len(u.sep)
+		}
+		cur.WriteString(u.text)
+		curLen += n
+	}
+	if curLen > 0 {
+		parts = append(parts, cur.String())
+	}
+	return parts
+}
+
+func runeCount(s string) int {
+	return utf8.RuneCountInString(s)
+}
+
+// byteOffset returns the byte offset of the n-th rune in s.
+func byteOffset(s string, n int) int {
+	for i := range s {
+		if n == 0 {
+			return i
+		}
+		n--
+	}
+	return len(s)
+}
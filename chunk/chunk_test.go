@@ -0,0 +1,150 @@
+// Copyright 2026 Conductor OSS
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+
+package chunk
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	markitdown "github.com/conductor-oss/markitdown"
+)
+
+func TestSplitHeadings(t *testing.T) {
+	md := "# Guide\n\n## Install\n\nRun the installer.\n\n## Usage\n\n### CLI\n\nCall it.\n\n# Appendix\n\nMore."
+	chunks := Split(md, Options{})
+
+	want := []struct {
+		headings []string
+		first    string
+	}{
+		{[]string{"Guide", "Install"}, "# Guide"},
+		{[]string{"Guide", "Usage", "CLI"}, "## Usage"},
+		{[]string{"Appendix"}, "# Appendix"},
+	}
+	if len(chunks) != len(want) {
+		t.Fatalf("got %d chunks, want %d: %+v", len(chunks), len(want), chunks)
+	}
+	for i, w := range want {
+		if !reflect.DeepEqual(chunks[i].Headings, w.headings) {
+			t.Errorf("chunk %d headings = %q, want %q", i, chunks[i].Headings, w.headings)
+		}
+		if !strings.HasPrefix(chunks[i].Markdown, w.first) {
+			t.Errorf("chunk %d = %q, want prefix %q", i, chunks[i].Markdown, w.first)
+		}
+		if chunks[i].Index != i {
+			t.Errorf("chunk %d index = %d", i, chunks[i].Index)
+		}
+	}
+}
+
+func TestSplitKeepsCodeBlocks(t *testing.T) {
+	code := "```go\n" + strings.Repeat("fmt.Println(\"x\")\n\n", 20) + "```"
+	md := "Intro paragraph.\n\n" + code + "\n\nOutro."
+	chunks := Split(md, Options{MaxChars: 50})
+
+	found := false
+	for _, c := range chunks {
+		if strings.Contains(c.Markdown, "```go") {
+			found = true
+			if !strings.Contains(c.Markdown, code) {
+				t.Errorf("code block was split: %q", c.Markdown)
+			}
+		}
+	}
+	if !found {
+		t.Fatalf("code block missing from chunks: %+v", chunks)
+	}
+}
+
+func TestSplitTableRepeatsHeader(t *testing.T) {
+	// The table may follow a paragraph line without a blank line between.
+	for _, intro := range []string{"", "Intro\n"} {
+		var b strings.Builder
+		b.WriteString(intro)
+		b.WriteString("| id | name |\n| --- | --- |\n")
+		for i := 0; i < 30; i++ {
+			fmt.Fprintf(&b, "| %d | row %d |\n", i, i)
+		}
+		chunks := Split(b.String(), Options{MaxTokens: 25})
+		if len(chunks) < 2 {
+			t.Fatalf("intro %q: expected the table to be split, got %d chunk(s)", intro, len(chunks))
+		}
+
+		rows := 0
+		for _, c := range chunks {
+			table := strings.TrimPrefix(c.Markdown, "Intro\n\n")
+			if table == "Intro" {
+				continue
+			}
+			lines := strings.Split(table, "\n")
+			if lines[0] != "| id | name |" || lines[1] != "| --- | --- |" {
+				t.Errorf("intro %q: chunk does not start with the table header: %q", intro, c.Markdown)
+			}
+			if n := len([]rune(c.Markdown)); n > 100 {
+				t.Errorf("intro %q: chunk has %d chars, want <= 100", intro, n)
+			}
+			rows += len(lines) - 2
+		}
+		if rows != 30 {
+			t.Errorf("intro %q: got %d rows across chunks, want 30", intro, rows)
+		}
+	}
+}
+
+func TestSplitLongText(t *testing.T) {
+	md := strings.Repeat("word ", 100)
+	chunks := Split(md, Options{MaxChars: 60})
+	var words int
+	for _, c := range chunks {
+		if n := len([]rune(c.Markdown)); n > 60 {
+			t.Errorf("chunk has %d chars, want <= 60", n)
+		}
+		words += len(strings.Fields(c.Markdown))
+	}
+	if words != 100 {
+		t.Errorf("got %d words, want 100", words)
+	}
+}
+
+func TestFromResultLocators(t *testing.T) {
+	result := &markitdown.DocumentConverterResult{
+		Segments: []markitdown.Segment{
+			{Kind: markitdown.SegmentPage, Number: 1, Markdown: "# Report\n\nFirst page."},
+			{Kind: markitdown.SegmentPage, Number: 2, Markdown: "Second page."},
+		},
+	}
+	chunks := FromResult(result, Options{})
+	if len(chunks) != 2 {
+		t.Fatalf("got %d chunks, want 2", len(chunks))
+	}
+	for i, c := range chunks {
+		if c.Source.Kind != markitdown.SegmentPage || c.Source.Number != i+1 {
+			t.Errorf("chunk %d source = %+v, want page %d", i, c.Source, i+1)
+		}
+		if !reflect.DeepEqual(c.Headings, []string{"Report"}) {
+			t.Errorf("chunk %d headings = %q, want [Report]", i, c.Headings)
+		}
+	}
+
+	entries := &markitdown.DocumentConverterResult{
+		Segments: []markitdown.Segment{
+			{Kind: markitdown.SegmentEntry, Number: 1, Name: "a.md", Markdown: "# A\n\nalpha"},
+			{Kind: markitdown.SegmentEntry, Number: 2, Name: "b.txt", Markdown: "beta"},
+		},
+	}
+	chunks = FromResult(entries, Options{})
+	if len(chunks) != 2 || chunks[1].Source.Name != "b.txt" || len(chunks[1].Headings) != 0 {
+		t.Errorf("entry chunks = %+v, want breadcrumb reset for b.txt", chunks)
+	}
+}
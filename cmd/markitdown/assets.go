@@ -0,0 +1,56 @@
+// Copyright 2026 Conductor OSS
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+
+package main
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	markitdown "github.com/conductor-oss/markitdown"
+)
+
+// assetLinks stores extracted images in a directory (--assets-dir) and makes
+// the links to them relative to each Markdown file. The asset writer links
+// assets by the absolute directory path, which relink replaces.
+type assetLinks struct {
+	dir string // absolute
+}
+
+func newAssetLinks(dir string) (*assetLinks, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &assetLinks{dir: abs}, nil
+}
+
+// writer returns the asset writer to configure MarkItDown with.
+func (a *assetLinks) writer() markitdown.AssetWriter {
+	return &markitdown.DirAssetWriter{Dir: a.dir, LinkPrefix: filepath.ToSlash(a.dir)}
+}
+
+// relink rewrites the asset links in md for a Markdown file in mdDir. It is a
+// no-op when no assets directory is configured.
+func (a *assetLinks) relink(md, mdDir string) string {
+	if a == nil {
+		return md
+	}
+	rel := a.dir
+	if absDir, err := filepath.Abs(mdDir); err == nil {
+		if r, err := filepath.Rel(absDir, a.dir); err == nil {
+			rel = r
+		}
+	}
+	rel = (&url.URL{Path: filepath.ToSlash(rel)}).EscapedPath()
+	return strings.ReplaceAll(md, "]("+filepath.ToSlash(a.dir)+"/", "]("+rel+"/")
+}
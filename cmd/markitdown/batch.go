@@ -0,0 +1,329 @@
+// Copyright 2026 Conductor OSS
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	markitdown "github.com/conductor-oss/markitdown"
+)
+
+// batchInput is a single document to convert in batch mode.
+type batchInput struct {
+	source string // file path or URL passed to MarkItDown.Convert
+	rel    string // slash-separated path relative to its source root
+}
+
+// batchOptions configures a batch conversion.
+type batchOptions struct {
+	outputDir string
+	include   []string
+	exclude   []string
+	jobs      int
+	assets    *assetLinks // --assets-dir, if set
+}
+
+// batchSummary counts batch outcomes.
+type batchSummary struct {
+	converted   int
+	failed      int
+	unsupported int
+}
+
+// stringList is a repeatable string flag.
+type stringList []string
+
+func (l *stringList) String() string { return strings.Join(*l, ",") }
+
+func (l *stringList) Set(v string) error {
+	*l = append(*l, v)
+	return nil
+}
+
+// isURL reports whether source should be fetched over HTTP.
+func isURL(source string) bool {
+	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+}
+
+// collectInputs expands sources (files, directories, glob patterns and URLs)
+// into the list of documents to convert. Directories are walked recursively.
+func collectInputs(sources []string, opts batchOptions) ([]batchInput, error) {
+	var inputs []batchInput
+	for _, source := range sources {
+		if isURL(source) {
+			inputs = append(inputs, batchInput{source: source, rel: urlRelPath(source)})
+			continue
+		}
+
+		if hasGlobMeta(source) {
+			matches, err := expandGlob(source)
+			if err != nil {
+				return nil, err
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("no files match %q", source)
+			}
+			inputs = append(inputs, filterInputs(matches, opts)...)
+			continue
+		}
+
+		st, err := os.Stat(source)
+		if err != nil {
+			return nil, err
+		}
+		if !st.IsDir() {
+			inputs = append(inputs, batchInput{source: source, rel: filepath.Base(source)})
+			continue
+		}
+
+		var found []batchInput
+		err = filepath.WalkDir(source, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			rel, err := filepath.Rel(source, p)
+			if err != nil {
+				return err
+			}
+			found = append(found, batchInput{source: p, rel: filepath.ToSlash(rel)})
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, filterInputs(found, opts)...)
+	}
+	return inputs, nil
+}
+
+// filterInputs applies the include and exclude patterns.
+func filterInputs(inputs []batchInput, opts batchOptions) []batchInput {
+	var out []batchInput
+	for _, in := range inputs {
+		if len(opts.include) > 0 && !matchAny(opts.include, in.rel) {
+			continue
+		}
+		if matchAny(opts.exclude, in.rel) {
+			continue
+		}
+		out = append(out, in)
+	}
+	return out
+}
+
+// matchAny reports whether rel matches any pattern. Patterns without a slash
+// are matched against the base name only.
+func matchAny(patterns []string, rel string) bool {
+	for _, p := range patterns {
+		p = filepath.ToSlash(p)
+		target := rel
+		if !strings.Contains(p, "/") {
+			target = path.Base(rel)
+		}
+		if matchGlob(p, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasGlobMeta(s string) bool {
+	return strings.ContainsAny(s, "*?[")
+}
+
+// expandGlob walks the non-magic prefix of pattern and returns the matching
+// files. Unlike filepath.Glob it understands "**" (any number of directories).
+func expandGlob(pattern string) ([]batchInput, error) {
+	pattern = filepath.ToSlash(pattern)
+	segments := strings.Split(pattern, "/")
+	baseLen := 0
+	for baseLen < len(segments) && !hasGlobMeta(segments[baseLen]) {
+		baseLen++
+	}
+	base := strings.Join(segments[:baseLen], "/")
+	if base == "" {
+		base = "."
+		if strings.HasPrefix(pattern, "/") {
+			base = "/"
+		}
+	}
+	rest := strings.Join(segments[baseLen:], "/")
+
+	var matches []batchInput
+	err := filepath.WalkDir(filepath.FromSlash(base), func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(filepath.FromSlash(base), p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if matchGlob(rest, rel) {
+			matches = append(matches, batchInput{source: p, rel: rel})
+		}
+		return nil
+	})
+	return matches, err
+}
+
+// matchGlob matches a slash-separated path against a pattern in which "**"
+// matches zero or more path segments and other segments use path.Match syntax.
+func matchGlob(pattern, name string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, err := path.Match(pattern[0], name[0]); err != nil || !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// urlRelPath derives an output-relative path for a URL source. Like the
+// paths of local sources it stays inside the output directory: ".."
+// elements, including escaped ones, are resolved against the URL's root.
+func urlRelPath(source string) string {
+	u, err := url.Parse(source)
+	if err != nil {
+		return "index"
+	}
+	p := strings.Trim(path.Clean("/"+u.Path), "/")
+	if p == "" {
+		p = "index"
+	}
+	rel := path.Join(u.Host, p)
+	for rel == ".." || strings.HasPrefix(rel, "../") {
+		rel = strings.TrimPrefix(rel[len(".."):], "/")
+	}
+	if rel == "" {
+		return "index"
+	}
+	return rel
+}
+
+// outputPath maps an input to its .md file under dir, mirroring the source tree.
+func outputPath(dir, rel string) string {
+	rel = strings.TrimSuffix(rel, path.Ext(rel)) + ".md"
+	return filepath.Join(dir, filepath.FromSlash(rel))
+}
+
+// runBatch converts inputs with a pool of opts.jobs workers and writes each
+// result under opts.outputDir. Per-file outcomes are reported to logw.
+func runBatch(ctx context.Context, m *markitdown.MarkItDown, inputs []batchInput, opts batchOptions, logw io.Writer) batchSummary {
+	jobs := opts.jobs
+	if jobs < 1 {
+		jobs = 1
+	}
+
+	// Reject output collisions (e.g. report.pdf and report.docx) up front.
+	outputs := make([]string, len(inputs))
+	claimed := make(map[string]string, len(inputs))
+	var summary batchSummary
+	var mu sync.Mutex
+	for i, in := range inputs {
+		out := outputPath(opts.outputDir, in.rel)
+		if prev, ok := claimed[out]; ok {
+			fmt.Fprintf(logw, "FAIL %s: output %s already written for %s\n", in.source, out, prev)
+			summary.failed++
+			continue
+		}
+		claimed[out] = in.source
+		outputs[i] = out
+	}
+
+	work := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < jobs; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				in := inputs[i]
+				err := convertToFile(ctx, m, in.source, outputs[i], opts.assets)
+
+				mu.Lock()
+				switch {
+				case err == nil:
+					summary.converted++
+					fmt.Fprintf(logw, "OK   %s -> %s\n", in.source, outputs[i])
+				case markitdown.IsUnsupportedFormat(err):
+					summary.unsupported++
+					fmt.Fprintf(logw, "SKIP %s: %v\n", in.source, err)
+				default:
+					summary.failed++
+					fmt.Fprintf(logw, "FAIL %s: %v\n", in.source, err)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for i := range inputs {
+		if outputs[i] == "" {
+			continue
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		work <- i
+	}
+	close(work)
+	wg.Wait()
+
+	return summary
+}
+
+// convertToFile converts source and writes the markdown to out.
+func convertToFile(ctx context.Context, m *markitdown.MarkItDown, source, out string, assets *assetLinks) error {
+	result, err := m.ConvertContext(ctx, source)
+	if err != nil {
+		return err
+	}
+	result.Markdown = assets.relink(result.Markdown, filepath.Dir(out))
+	if err := os.MkdirAll(filepath.Dir(out), 0o755); err != nil {
+		return fmt.Errorf("create directory: %w", err)
+	}
+	if err := os.WriteFile(out, []byte(result.Markdown+"\n"), 0o644); err != nil {
+		return fmt.Errorf("write output: %w", err)
+	}
+	return nil
+}
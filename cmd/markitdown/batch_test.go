@@ -0,0 +1,153 @@
+// Copyright 2026 Conductor OSS
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	markitdown "github.com/conductor-oss/markitdown"
+)
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"*.pdf", "a.pdf", true},
+		{"*.pdf", "dir/a.pdf", false},
+		{"**/*.pdf", "a.pdf", true},
+		{"**/*.pdf", "x/y/a.pdf", true},
+		{"docs/**", "docs/x/y.md", true},
+		{"docs/**", "other/y.md", false},
+		{"x/*/z.txt", "x/y/z.txt", true},
+	}
+	for _, tt := range tests {
+		if got := matchGlob(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("matchGlob(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestURLRelPath(t *testing.T) {
+	tests := []struct {
+		source string
+		want   string
+	}{
+		{"https://example.com/", "example.com/index"},
+		{"https://example.com/docs/report.pdf", "example.com/docs/report.pdf"},
+		{"https://example.com/a/../b.pdf", "example.com/b.pdf"},
+		{"https://h/%2e%2e/%2e%2e/x", "h/x"},
+		{"https://h/../../../etc/passwd", "h/etc/passwd"},
+		{"https://../x", "x"},
+	}
+	for _, tt := range tests {
+		if got := urlRelPath(tt.source); got != tt.want {
+			t.Errorf("urlRelPath(%q) = %q, want %q", tt.source, got, tt.want)
+		}
+	}
+}
+
+func TestBatchConvert(t *testing.T) {
+	src := t.TempDir()
+	out := t.TempDir()
+	files := map[string]string{
+		"a.txt":          "alpha",
+		"sub/b.csv":      "h1,h2\n1,2\n",
+		"sub/skip.txt":   "excluded",
+		"sub/deep/c.bin": "\x00\x01\x02",
+	}
+	for name, content := range files {
+		p := filepath.Join(src, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	opts := batchOptions{outputDir: out, exclude: []string{"skip.*"}, jobs: 2}
+	inputs, err := collectInputs([]string{src}, opts)
+	if err != nil {
+		t.Fatalf("collectInputs error: %v", err)
+	}
+	if len(inputs) != 3 {
+		t.Fatalf("got %d inputs, want 3: %v", len(inputs), inputs)
+	}
+
+	summary := runBatch(context.Background(), markitdown.New(), inputs, opts, io.Discard)
+	if summary.converted != 2 || summary.failed != 0 || summary.unsupported != 1 {
+		t.Errorf("summary = %+v, want 2 converted, 0 failed, 1 unsupported", summary)
+	}
+
+	for _, name := range []string{"a.md", "sub/b.md"} {
+		if _, err := os.Stat(filepath.Join(out, filepath.FromSlash(name))); err != nil {
+			t.Errorf("expected output %s: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(out, "sub", "skip.md")); err == nil {
+		t.Errorf("excluded file was converted")
+	}
+
+	globbed, err := collectInputs([]string{filepath.Join(src, "**", "*.csv")}, batchOptions{})
+	if err != nil {
+		t.Fatalf("collectInputs(glob) error: %v", err)
+	}
+	if len(globbed) != 1 || globbed[0].rel != "sub/b.csv" {
+		t.Errorf("glob inputs = %v, want sub/b.csv", globbed)
+	}
+}
+
+func TestBatchAssets(t *testing.T) {
+	src := t.TempDir()
+	out := t.TempDir()
+	page := `<html><body><img src="data:image/gif;base64,R0lGODlhAQABAAAAACw=" alt="dot"></body></html>`
+	if err := os.MkdirAll(filepath.Join(src, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "sub", "page.html"), []byte(page), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	assets, err := newAssetLinks(filepath.Join(out, "assets"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := markitdown.New(markitdown.WithAssetWriter(assets.writer()))
+	opts := batchOptions{outputDir: out, jobs: 1, assets: assets}
+	inputs, err := collectInputs([]string{src}, opts)
+	if err != nil {
+		t.Fatalf("collectInputs error: %v", err)
+	}
+	if summary := runBatch(context.Background(), m, inputs, opts, io.Discard); summary.converted != 1 {
+		t.Fatalf("summary = %+v, want 1 converted", summary)
+	}
+
+	md, err := os.ReadFile(filepath.Join(out, "sub", "page.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err := os.ReadDir(filepath.Join(out, "assets"))
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("assets = %v, %v; want one file", entries, err)
+	}
+	want := "![dot](../assets/" + entries[0].Name() + ")"
+	if !strings.Contains(string(md), want) {
+		t.Errorf("markdown = %q, want link %q", md, want)
+	}
+}
@@ -12,11 +12,14 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"runtime"
 	"strings"
 
 	markitdown "github.com/conductor-oss/markitdown"
@@ -25,13 +28,33 @@ import (
 var version = "dev"
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "serve":
+			os.Exit(runServe(os.Args[2:]))
+		case "mcp":
+			os.Exit(runMCP(os.Args[2:]))
+		case "worker":
+			os.Exit(runWorker(os.Args[2:]))
+		}
+	}
+
 	var (
-		output       string
-		extension    string
-		mimeType     string
-		charset      string
-		showVersion  bool
-		keepDataURIs bool
+		output         string
+		extension      string
+		mimeType       string
+		charset        string
+		showVersion    bool
+		keepDataURIs   bool
+		outputDir      string
+		include        stringList
+		exclude        stringList
+		jobs           int
+		tracked        string
+		headersFooters bool
+		frontMatter    bool
+		assetsDir      string
+		password       string
 	)
 
 	flag.StringVar(&output, "o", "", "Output file (default: stdout)")
@@ -45,12 +68,28 @@ func main() {
 	flag.BoolVar(&showVersion, "v", false, "Show version")
 	flag.BoolVar(&showVersion, "version", false, "Show version")
 	flag.BoolVar(&keepDataURIs, "keep-data-uris", false, "Keep full base64-encoded data URIs")
+	flag.StringVar(&tracked, "tracked-changes", "accept", "DOCX tracked changes: accept, reject or inline")
+	flag.BoolVar(&headersFooters, "headers-footers", false, "Include DOCX page headers and footers, and PDF running headers and footers")
+	flag.BoolVar(&frontMatter, "front-matter", false, "Prepend document metadata as YAML front matter")
+	flag.StringVar(&password, "password", "", "Password for encrypted PDFs")
+	flag.StringVar(&assetsDir, "assets-dir", "", "Write embedded images to this directory and link them instead of inlining")
+	flag.StringVar(&outputDir, "d", "", "Output directory for batch conversion (mirrors the source tree)")
+	flag.StringVar(&outputDir, "output-dir", "", "Output directory for batch conversion (mirrors the source tree)")
+	flag.Var(&include, "include", "Only convert files matching this glob (repeatable)")
+	flag.Var(&exclude, "exclude", "Skip files matching this glob (repeatable)")
+	flag.IntVar(&jobs, "j", runtime.NumCPU(), "Number of parallel conversions in batch mode")
+	flag.IntVar(&jobs, "jobs", runtime.NumCPU(), "Number of parallel conversions in batch mode")
 
 	flag.Usage = func() {
-		fmt.Fprintf(os.Stderr, "Usage: markitdown [flags] [source]\n\n")
+		fmt.Fprintf(os.Stderr, "Usage: markitdown [flags] [source...]\n")
+		fmt.Fprintf(os.Stderr, "       markitdown serve [flags]\n")
+		fmt.Fprintf(os.Stderr, "       markitdown mcp [flags]\n")
+		fmt.Fprintf(os.Stderr, "       markitdown worker [flags]\n\n")
 		fmt.Fprintf(os.Stderr, "Convert documents to Markdown.\n\n")
 		fmt.Fprintf(os.Stderr, "Arguments:\n")
-		fmt.Fprintf(os.Stderr, "  source    File path or URL to convert (reads stdin if omitted)\n\n")
+		fmt.Fprintf(os.Stderr, "  source    File path, directory, glob (\"docs/**/*.pdf\") or URL to convert\n")
+		fmt.Fprintf(os.Stderr, "            (reads stdin if omitted). Several sources, directories and\n")
+		fmt.Fprintf(os.Stderr, "            globs require --output-dir.\n\n")
 		fmt.Fprintf(os.Stderr, "Flags:\n")
 		flag.PrintDefaults()
 	}
@@ -70,18 +109,86 @@ func main() {
 		}
 	}
 
-	// Create MarkItDown instance
-	var opts []markitdown.Option
+	// Create MarkItDown instance. URLs on the command line come from the
+	// operator, so private hosts such as localhost are allowed. PDF engine
+	// instances start on demand, so allowing one per batch job costs nothing
+	// for single conversions.
+	opts := []markitdown.Option{
+		markitdown.WithUserAgent("markitdown/" + version),
+		markitdown.WithHostPolicy(markitdown.HostPolicy{AllowPrivate: true}),
+		markitdown.WithPDFWorkers(jobs),
+	}
 	if keepDataURIs {
 		opts = append(opts, markitdown.WithKeepDataURIs(true))
 	}
+	switch tracked {
+	case "accept":
+	case "reject":
+		opts = append(opts, markitdown.WithTrackedChanges(markitdown.TrackedChangesReject))
+	case "inline":
+		opts = append(opts, markitdown.WithTrackedChanges(markitdown.TrackedChangesInline))
+	default:
+		fmt.Fprintf(os.Stderr, "Error: --tracked-changes must be accept, reject or inline\n")
+		os.Exit(2)
+	}
+	if headersFooters {
+		opts = append(opts, markitdown.WithHeadersFooters(true))
+	}
+	if frontMatter {
+		opts = append(opts, markitdown.WithFrontMatter(true))
+	}
+	if password != "" {
+		opts = append(opts, markitdown.WithPassword(password))
+	}
+	var assets *assetLinks
+	if assetsDir != "" {
+		var err error
+		if assets, err = newAssetLinks(assetsDir); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		opts = append(opts, markitdown.WithAssetWriter(assets.writer()))
+	}
 	m := markitdown.New(opts...)
 
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
 	var result *markitdown.DocumentConverterResult
 	var err error
 
 	args := flag.Args()
 
+	if outputDir != "" || len(args) > 1 || (len(args) == 1 && isBatchSource(args[0])) {
+		if outputDir == "" {
+			fmt.Fprintf(os.Stderr, "Error: converting several files, directories or globs requires --output-dir\n")
+			os.Exit(2)
+		}
+		if len(args) == 0 {
+			fmt.Fprintf(os.Stderr, "Error: --output-dir requires at least one source\n")
+			os.Exit(2)
+		}
+		bopts := batchOptions{
+			outputDir: outputDir,
+			include:   include,
+			exclude:   exclude,
+			jobs:      jobs,
+			assets:    assets,
+		}
+		inputs, err := collectInputs(args, bopts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		summary := runBatch(ctx, m, inputs, bopts, os.Stderr)
+		fmt.Fprintf(os.Stderr, "\n%d converted, %d failed, %d unsupported\n",
+			summary.converted, summary.failed, summary.unsupported)
+		if summary.failed > 0 || ctx.Err() != nil {
+			os.Exit(1)
+		}
+		return
+	}
+
 	if len(args) == 0 {
 		// Read from stdin
 		data, readErr := io.ReadAll(os.Stdin)
@@ -100,14 +207,9 @@ func main() {
 		if info.MIMEType == "" && info.Extension != "" {
 			info.MIMEType = mimeFromExt(info.Extension)
 		}
-		result, err = m.ConvertReader(reader, info)
+		result, err = m.ConvertReaderContext(ctx, reader, info)
 	} else {
-		source := args[0]
-		if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
-			result, err = m.ConvertURL(source)
-		} else {
-			result, err = m.ConvertFile(source)
-		}
+		result, err = m.ConvertContext(ctx, args[0])
 	}
 
 	if err != nil {
@@ -116,6 +218,7 @@ func main() {
 	}
 
 	// Write output
+	result.Markdown = assets.relink(result.Markdown, filepath.Dir(output))
 	if output != "" {
 		dir := filepath.Dir(output)
 		if dir != "." {
@@ -134,6 +237,18 @@ func main() {
 	}
 }
 
+// isBatchSource reports whether a single source expands to several files.
+func isBatchSource(source string) bool {
+	if isURL(source) {
+		return false
+	}
+	if hasGlobMeta(source) {
+		return true
+	}
+	st, err := os.Stat(source)
+	return err == nil && st.IsDir()
+}
+
 func newBytesReadSeeker(data []byte) io.ReadSeeker {
 	return strings.NewReader(string(data))
 }
@@ -0,0 +1,54 @@
+// Copyright 2026 Conductor OSS
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	markitdown "github.com/conductor-oss/markitdown"
+	"github.com/conductor-oss/markitdown/mcp"
+)
+
+// runMCP implements "markitdown mcp".
+func runMCP(args []string) int {
+	fs := flag.NewFlagSet("mcp", flag.ExitOnError)
+	var keepDataURIs, allowPrivateHosts bool
+	fs.BoolVar(&keepDataURIs, "keep-data-uris", false, "Keep full base64-encoded data URIs")
+	fs.BoolVar(&allowPrivateHosts, "allow-private-hosts", false, "Allow fetching URLs on private and loopback addresses")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: markitdown mcp [flags]\n\n")
+		fmt.Fprintf(os.Stderr, "Run a Model Context Protocol server on stdin/stdout.\n\n")
+		fmt.Fprintf(os.Stderr, "Flags:\n")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	m := markitdown.New(
+		markitdown.WithKeepDataURIs(keepDataURIs),
+		markitdown.WithUserAgent("markitdown/"+version),
+		markitdown.WithHostPolicy(markitdown.HostPolicy{AllowPrivate: allowPrivateHosts}),
+	)
+	defer m.Close()
+	if err := mcp.NewServer(m, version).Serve(ctx, os.Stdin, os.Stdout); err != nil && ctx.Err() == nil {
+		fmt.Fprintf(os.Stderr, "mcp: %v\n", err)
+		return 1
+	}
+	return 0
+}
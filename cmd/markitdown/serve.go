@@ -0,0 +1,98 @@
+// Copyright 2026 Conductor OSS
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"runtime"
+	"syscall"
+	"time"
+
+	markitdown "github.com/conductor-oss/markitdown"
+	"github.com/conductor-oss/markitdown/server"
+)
+
+// runServe implements "markitdown serve".
+func runServe(args []string) int {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	var (
+		addr            string
+		maxRequestBytes int64
+		timeout         time.Duration
+		maxConcurrent   int
+		keepDataURIs    bool
+	)
+	fs.StringVar(&addr, "addr", ":8080", "Listen address")
+	fs.Int64Var(&maxRequestBytes, "max-request-bytes", 32<<20, "Maximum request body size in bytes")
+	fs.DurationVar(&timeout, "timeout", 60*time.Second, "Per-request conversion timeout")
+	fs.IntVar(&maxConcurrent, "max-concurrent", 0, "Maximum concurrent conversions (default: CPU count)")
+	fs.BoolVar(&keepDataURIs, "keep-data-uris", false, "Keep full base64-encoded data URIs")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: markitdown serve [flags]\n\n")
+		fmt.Fprintf(os.Stderr, "Serve the conversion API over HTTP (POST /convert, GET /healthz, GET /readyz).\n\n")
+		fmt.Fprintf(os.Stderr, "Flags:\n")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	if maxConcurrent <= 0 {
+		maxConcurrent = runtime.NumCPU()
+	}
+	m := markitdown.New(
+		markitdown.WithKeepDataURIs(keepDataURIs),
+		markitdown.WithPDFWorkers(maxConcurrent),
+	)
+	defer m.Close()
+	handler := server.New(m, server.Config{
+		MaxRequestBytes: maxRequestBytes,
+		Timeout:         timeout,
+		MaxConcurrent:   maxConcurrent,
+	})
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errc := make(chan error, 1)
+	go func() {
+		log.Printf("markitdown serving on %s", addr)
+		errc <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errc:
+		log.Printf("serve: %v", err)
+		return 1
+	case <-ctx.Done():
+	}
+
+	log.Printf("shutting down")
+	handler.SetReady(false)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout+5*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Printf("shutdown: %v", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,82 @@
+// Copyright 2026 Conductor OSS
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"runtime"
+	"syscall"
+	"time"
+
+	markitdown "github.com/conductor-oss/markitdown"
+	"github.com/conductor-oss/markitdown/worker"
+)
+
+// runWorker implements "markitdown worker".
+func runWorker(args []string) int {
+	fs := flag.NewFlagSet("worker", flag.ExitOnError)
+	var (
+		cfg               worker.Config
+		keepDataURIs      bool
+		allowPrivateHosts bool
+	)
+	fs.StringVar(&cfg.ServerURL, "server", os.Getenv("CONDUCTOR_SERVER_URL"), "Conductor API URL, e.g. http://localhost:8080/api (env CONDUCTOR_SERVER_URL)")
+	fs.StringVar(&cfg.TaskType, "task-type", worker.DefaultTaskType, "Task type to poll")
+	fs.StringVar(&cfg.WorkerID, "worker-id", "", "Worker ID reported to Conductor (default: hostname)")
+	fs.StringVar(&cfg.Domain, "domain", "", "Task domain")
+	fs.IntVar(&cfg.Concurrency, "concurrency", 0, "Tasks processed in parallel (default: CPU count)")
+	fs.DurationVar(&cfg.PollInterval, "poll-interval", time.Second, "Wait between empty polls")
+	fs.DurationVar(&cfg.TaskTimeout, "timeout", 5*time.Minute, "Per-task conversion timeout")
+	fs.BoolVar(&keepDataURIs, "keep-data-uris", false, "Keep full base64-encoded data URIs")
+	fs.BoolVar(&allowPrivateHosts, "allow-private-hosts", false, "Allow fetching URLs on private and loopback addresses")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: markitdown worker [flags]\n\n")
+		fmt.Fprintf(os.Stderr, "Poll a Conductor server for %q tasks and convert their documents.\n", worker.DefaultTaskType)
+		fmt.Fprintf(os.Stderr, "Credentials are read from CONDUCTOR_AUTH_KEY and CONDUCTOR_AUTH_SECRET.\n\n")
+		fmt.Fprintf(os.Stderr, "Flags:\n")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	if cfg.ServerURL == "" {
+		fmt.Fprintln(os.Stderr, "worker: --server or CONDUCTOR_SERVER_URL is required")
+		return 2
+	}
+	cfg.AuthKey = os.Getenv("CONDUCTOR_AUTH_KEY")
+	cfg.AuthSecret = os.Getenv("CONDUCTOR_AUTH_SECRET")
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = runtime.NumCPU()
+	}
+	m := markitdown.New(
+		markitdown.WithKeepDataURIs(keepDataURIs),
+		markitdown.WithUserAgent("markitdown/"+version),
+		markitdown.WithHostPolicy(markitdown.HostPolicy{AllowPrivate: allowPrivateHosts}),
+		markitdown.WithPDFWorkers(cfg.Concurrency),
+	)
+	defer m.Close()
+	log.Printf("markitdown worker polling %s for %s tasks", cfg.ServerURL, cfg.TaskType)
+	if err := worker.New(m, cfg).Run(ctx); err != nil {
+		log.Printf("worker: %v", err)
+		return 1
+	}
+	log.Printf("worker stopped")
+	return 0
+}
@@ -11,7 +11,12 @@
 
 package markitdown
 
-import "io"
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+)
 
 // StreamInfo holds metadata about the input being converted.
 type StreamInfo struct {
@@ -21,12 +26,93 @@ type StreamInfo struct {
 	Filename  string
 	LocalPath string
 	URL       string
+
+	// FinalURL is the URL the content was served from after redirects, and
+	// Header the HTTP response headers, when the input was fetched by ConvertURL.
+	FinalURL string
+	Header   http.Header
+
+	// Password opens encrypted documents (PDF), overriding
+	// ContextWithPassword and WithPassword.
+	// Conversion fails with a PasswordRequiredError when one is needed and it
+	// is missing or wrong.
+	Password string
 }
 
 // DocumentConverterResult holds the output of a conversion.
 type DocumentConverterResult struct {
 	Markdown string
 	Title    string
+
+	// Metadata holds document properties such as author and dates, keyed by
+	// the Metadata* constants. Converters omit keys they cannot determine.
+	Metadata map[string]string
+
+	// Segments lists the document's natural units (pages, slides, sheets,
+	// chapters, archive entries) in order, when the format has them.
+	Segments []Segment
+
+	// Warnings records non-fatal problems encountered during conversion,
+	// such as archive entries that were skipped.
+	Warnings []string
+}
+
+// Well-known DocumentConverterResult.Metadata keys.
+const (
+	MetadataTitle          = "title"
+	MetadataAuthor         = "author"
+	MetadataCreated        = "created"
+	MetadataModified       = "modified"
+	MetadataLanguage       = "language"
+	MetadataPageCount      = "page_count"
+	MetadataSubject        = "subject"
+	MetadataDescription    = "description"
+	MetadataKeywords       = "keywords"
+	MetadataCategory       = "category"
+	MetadataLastModifiedBy = "last_modified_by"
+	MetadataCompany        = "company"
+)
+
+// MetadataCustomPrefix prefixes the names of user-defined document properties
+// (OOXML docProps/custom.xml) in DocumentConverterResult.Metadata, e.g.
+// "custom.Client".
+const MetadataCustomPrefix = "custom."
+
+// SegmentKind identifies the kind of unit a Segment represents.
+type SegmentKind string
+
+// Segment kinds produced by the built-in converters.
+const (
+	SegmentPage    SegmentKind = "page"    // PDF page
+	SegmentSlide   SegmentKind = "slide"   // PPTX slide
+	SegmentSheet   SegmentKind = "sheet"   // XLSX/XLS worksheet
+	SegmentChapter SegmentKind = "chapter" // EPUB spine item
+	SegmentEntry   SegmentKind = "entry"   // ZIP archive entry
+)
+
+// Segment is one unit of a converted document together with its locator.
+type Segment struct {
+	Kind SegmentKind
+	// Number is the 1-based position of the unit within the source, counting
+	// units that produced no segment: the page, slide or sheet number, the
+	// spine position of a chapter, or the position of an entry among the
+	// files of an archive.
+	Number int
+	// Name is the sheet name, chapter file or archive entry path, if any.
+	Name     string
+	Markdown string
+}
+
+// setMetadata records a metadata value, ignoring empty values.
+func (r *DocumentConverterResult) setMetadata(key, value string) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return
+	}
+	if r.Metadata == nil {
+		r.Metadata = make(map[string]string)
+	}
+	r.Metadata[key] = value
 }
 
 // DocumentConverter is the interface all format converters implement.
@@ -38,3 +124,29 @@ type DocumentConverter interface {
 	// Convert performs the actual document-to-markdown conversion.
 	Convert(reader io.ReadSeeker, info StreamInfo) (*DocumentConverterResult, error)
 }
+
+// ContextDocumentConverter is an optional extension of DocumentConverter for
+// converters that support cancellation and deadlines. When a registered
+// converter implements it, MarkItDown calls ConvertContext instead of Convert.
+type ContextDocumentConverter interface {
+	DocumentConverter
+
+	// ConvertContext is like Convert but aborts when ctx is done. Long-running
+	// converters should check ctx between units of work (pages, entries, ...).
+	ConvertContext(ctx context.Context, reader io.ReadSeeker, info StreamInfo) (*DocumentConverterResult, error)
+}
+
+// FormatDescriber is an optional interface for converters that can list the
+// file extensions and MIME types they accept. It is used to advertise
+// supported formats (see MarkItDown.Formats); Accepts remains authoritative.
+type FormatDescriber interface {
+	Formats() (extensions, mimeTypes []string)
+}
+
+// FormatInfo describes a registered converter and the formats it accepts.
+type FormatInfo struct {
+	Converter  string
+	Priority   float64
+	Extensions []string
+	MIMETypes  []string
+}
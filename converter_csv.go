@@ -34,6 +34,10 @@ func (c *CsvConverter) Accepts(info StreamInfo) bool {
 	return strings.HasPrefix(mime, "text/csv") || strings.HasPrefix(mime, "application/csv")
 }
 
+func (c *CsvConverter) Formats() (extensions, mimeTypes []string) {
+	return []string{".csv"}, []string{"text/csv", "application/csv"}
+}
+
 func (c *CsvConverter) Convert(reader io.ReadSeeker, info StreamInfo) (*DocumentConverterResult, error) {
 	data, err := io.ReadAll(reader)
 	if err != nil {
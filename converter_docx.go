@@ -14,6 +14,7 @@ package markitdown
 import (
 	"archive/zip"
 	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/xml"
 	"fmt"
@@ -43,7 +44,16 @@ func (c *DocxConverter) Accepts(info StreamInfo) bool {
 	return strings.HasPrefix(mime, "application/vnd.openxmlformats-officedocument.wordprocessingml.document")
 }
 
+func (c *DocxConverter) Formats() (extensions, mimeTypes []string) {
+	return []string{".docx"}, []string{"application/vnd.openxmlformats-officedocument.wordprocessingml.document"}
+}
+
 func (c *DocxConverter) Convert(reader io.ReadSeeker, info StreamInfo) (*DocumentConverterResult, error) {
+	return c.ConvertContext(context.Background(), reader, info)
+}
+
+// ConvertContext converts a DOCX, checking ctx between paragraphs.
+func (c *DocxConverter) ConvertContext(ctx context.Context, reader io.ReadSeeker, info StreamInfo) (*DocumentConverterResult, error) {
 	data, err := io.ReadAll(reader)
 	if err != nil {
 		return nil, fmt.Errorf("read DOCX: %w", err)
@@ -53,6 +63,9 @@ func (c *DocxConverter) Convert(reader io.ReadSeeker, info StreamInfo) (*Documen
 	if err != nil {
 		return nil, fmt.Errorf("open DOCX ZIP: %w", err)
 	}
+	if err := checkArchive(ctx, zr); err != nil {
+		return nil, err
+	}
 
 	// Parse relationships for hyperlinks
 	rels, _ := ooxml.ParseRelationshipsFromReader(zr, "word/_rels/document.xml.rels")
@@ -66,6 +79,13 @@ func (c *DocxConverter) Convert(reader io.ReadSeeker, info StreamInfo) (*Documen
 	// Parse styles for heading detection
 	styles := c.parseStyles(zr)
 
+	// Parse the user style map (WithStyleMap)
+	var sm styleMap
+	var warnings []string
+	if c.markitdown != nil && c.markitdown.styleMap != "" {
+		sm, warnings = parseStyleMap(c.markitdown.styleMap)
+	}
+
 	// Read and parse document.xml
 	docData, err := ooxml.ReadFileFromZip(zr, "word/document.xml")
 	if err != nil {
@@ -75,30 +95,104 @@ func (c *DocxConverter) Convert(reader io.ReadSeeker, info StreamInfo) (*Documen
 	// Pre-process: convert OMML math to LaTeX
 	docData = c.preProcessMath(docData)
 
+	pkg := &docxPackage{
+		zr:        zr,
+		numbering: numbering,
+		comments:  comments,
+		styles:    styles,
+		styleMap:  sm,
+	}
+	if c.markitdown != nil {
+		pkg.trackedChanges = c.markitdown.trackedChanges
+		pkg.headersFooters = c.markitdown.headersFooters
+	}
+
 	// Parse the document body to HTML
-	htmlStr := c.documentToHTML(docData, rels, numbering, comments, styles, zr)
+	htmlStr, err := c.documentToHTML(ctx, docData, rels, pkg)
+	if err != nil {
+		return nil, err
+	}
 
 	// Convert HTML to markdown via the HTML converter
 	htmlConv := NewHTMLConverter(c.markitdown)
-	result, err := htmlConv.ConvertString(htmlStr)
+	result, err := htmlConv.convertString(ctx, htmlStr)
 	if err != nil {
 		return nil, fmt.Errorf("convert DOCX HTML to markdown: %w", err)
 	}
+	result.Markdown, err = c.renderNotes(ctx, result.Markdown, pkg)
+	if err != nil {
+		return nil, err
+	}
+	result.Markdown = renderRevisions(result.Markdown, pkg.revisions)
+	result.Warnings = append(result.Warnings, warnings...)
+	setDocProperties(result, zr)
 
 	return result, nil
 }
 
+// setDocProperties copies the OOXML core, extended and custom document
+// properties into the result metadata, and uses the core title as the result
+// title if the content did not provide one.
+func setDocProperties(result *DocumentConverterResult, zr *zip.Reader) {
+	core, err := ooxml.ParseCoreProperties(zr)
+	if err != nil {
+		result.Warnings = append(result.Warnings, err.Error())
+	}
+	result.setMetadata(MetadataTitle, core.Title)
+	result.setMetadata(MetadataAuthor, core.Creator)
+	result.setMetadata(MetadataCreated, core.Created)
+	result.setMetadata(MetadataModified, core.Modified)
+	result.setMetadata(MetadataLanguage, core.Language)
+	result.setMetadata(MetadataSubject, core.Subject)
+	result.setMetadata(MetadataDescription, core.Description)
+	result.setMetadata(MetadataKeywords, core.Keywords)
+	result.setMetadata(MetadataCategory, core.Category)
+	result.setMetadata(MetadataLastModifiedBy, core.LastModifiedBy)
+	if result.Title == "" {
+		result.Title = strings.TrimSpace(core.Title)
+	}
+
+	app, err := ooxml.ParseAppProperties(zr)
+	if err != nil {
+		result.Warnings = append(result.Warnings, err.Error())
+	}
+	result.setMetadata(MetadataCompany, app.Company)
+
+	custom, err := ooxml.ParseCustomProperties(zr)
+	if err != nil {
+		result.Warnings = append(result.Warnings, err.Error())
+	}
+	for _, p := range custom {
+		if p.Name != "" {
+			result.setMetadata(MetadataCustomPrefix+p.Name, p.Value)
+		}
+	}
+}
+
+// docxPackage holds the package-wide parts shared by every WordprocessingML
+// part converted from one DOCX.
+type docxPackage struct {
+	zr        *zip.Reader
+	numbering map[string]numberingDef
+	comments  map[string]docxComment
+	styles    map[string]styleInfo
+	styleMap  styleMap
+
+	trackedChanges TrackedChangesMode
+	revisions      []docxRevision // revisions marked in the output (TrackedChangesInline)
+
+	headersFooters     bool
+	seenHeadersFooters map[string]bool // converted header/footer HTML, for deduplication
+
+	listCounters map[string]map[int]int // current number per numberingDef.counter and level
+}
+
 // styleInfo holds style information for a document style.
 type styleInfo struct {
 	name    string
 	styleID string
 }
 
-// numberingDef holds a numbering definition.
-type numberingDef struct {
-	abstractNumID string
-}
-
 func (c *DocxConverter) parseStyles(zr *zip.Reader) map[string]styleInfo {
 	styles := make(map[string]styleInfo)
 	data, err := ooxml.ReadFileFromZip(zr, "word/styles.xml")
@@ -151,48 +245,6 @@ func (c *DocxConverter) parseStyles(zr *zip.Reader) map[string]styleInfo {
 	return styles
 }
 
-func (c *DocxConverter) parseNumbering(zr *zip.Reader) map[string]numberingDef {
-	numbering := make(map[string]numberingDef)
-	data, err := ooxml.ReadFileFromZip(zr, "word/numbering.xml")
-	if err != nil {
-		return numbering
-	}
-
-	// Simple XML parsing for numbering definitions
-	decoder := xml.NewDecoder(bytes.NewReader(data))
-	var currentNumID string
-	var inNum bool
-
-	for {
-		tok, err := decoder.Token()
-		if err != nil {
-			break
-		}
-		switch t := tok.(type) {
-		case xml.StartElement:
-			if t.Name.Local == "num" {
-				inNum = true
-				for _, attr := range t.Attr {
-					if attr.Name.Local == "numId" {
-						currentNumID = attr.Value
-					}
-				}
-			} else if inNum && t.Name.Local == "abstractNumId" {
-				for _, attr := range t.Attr {
-					if attr.Name.Local == "val" {
-						numbering[currentNumID] = numberingDef{abstractNumID: attr.Value}
-					}
-				}
-			}
-		case xml.EndElement:
-			if t.Name.Local == "num" {
-				inNum = false
-			}
-		}
-	}
-	return numbering
-}
-
 type docxComment struct {
 	id     string
 	author string
@@ -335,25 +387,30 @@ func findOMath(elm *docxmath.OMMLElement, results *[]string) {
 	}
 }
 
-// documentToHTML converts the document.xml content to HTML.
-func (c *DocxConverter) documentToHTML(docData []byte, rels map[string]ooxml.Relationship, numbering map[string]numberingDef, comments map[string]docxComment, styles map[string]styleInfo, zr *zip.Reader) string {
-	var html strings.Builder
-	html.WriteString("<html><body>")
-
-	decoder := xml.NewDecoder(bytes.NewReader(docData))
+// documentToHTML converts WordprocessingML content (the document body, or a
+// note or header part) to HTML. rels resolves the part's relationships.
+func (c *DocxConverter) documentToHTML(ctx context.Context, docData []byte, rels map[string]ooxml.Relationship, pkg *docxPackage) (string, error) {
+	body, err := c.blocksToHTML(ctx, xml.NewDecoder(bytes.NewReader(docData)), rels, pkg, "")
+	if err != nil {
+		return "", err
+	}
+	return "<html><body>" + body + "</body></html>", nil
+}
 
+// blocksToHTML converts the block content read from decoder to HTML, up to
+// the end of the enclosing element named until (or to the end of input when
+// until is empty). Text boxes are converted by recursive calls.
+func (c *DocxConverter) blocksToHTML(ctx context.Context, decoder *xml.Decoder, rels map[string]ooxml.Relationship, pkg *docxPackage, until string) (string, error) {
 	type state struct {
 		inParagraph bool
 		inRun       bool
 		inText      bool
-		inTable     bool
-		inTableRow  bool
-		inTableCell bool
 		bold        bool
 		italic      bool
 		underline   bool
 		strike      bool
 		styleID     string
+		runStyleID  string
 		hyperRef    string
 		inHyper     bool
 		listNumID   string
@@ -365,11 +422,79 @@ func (c *DocxConverter) documentToHTML(docData []byte, rels map[string]ooxml.Rel
 	var textBuf strings.Builder
 	var paragraphs []string
 	var currentPara strings.Builder
-	var tableRows [][]string
-	var currentRow []string
-	var cellContent strings.Builder
 	var commentRefs []string
+	var openRevisions []int // indexes into pkg.revisions
+
+	// Tables and cells are stacks: a cell may hold a nested table.
+	var tables []*docxTable
+	var cells []*docxTableCell
+	cell := func() *docxTableCell {
+		if len(cells) == 0 {
+			return nil
+		}
+		return cells[len(cells)-1]
+	}
+	write := func(text string) {
+		if tc := cell(); tc != nil {
+			tc.content.WriteString(text)
+		} else {
+			currentPara.WriteString(text)
+		}
+	}
 
+	// Consecutive paragraphs mapped by the same style map rule are collapsed
+	// into one block (e.g. a multi-paragraph code block or callout).
+	var groupMapping *styleMapping
+	var groupParas []string
+	// List paragraphs are likewise collected until the list ends, so that
+	// they can be nested by level.
+	var listItems []docxListItem
+	flushGroup := func() {
+		if groupMapping != nil {
+			if block := groupMapping.wrapBlock(groupParas); block != "" {
+				paragraphs = append(paragraphs, block)
+			}
+		}
+		groupMapping = nil
+		groupParas = nil
+		if len(listItems) > 0 {
+			paragraphs = append(paragraphs, renderList(listItems))
+			listItems = nil
+		}
+	}
+	styleName := func(styleID string) string {
+		if si, ok := pkg.styles[styleID]; ok {
+			return si.name
+		}
+		return ""
+	}
+
+	// Text boxes anchored in a paragraph follow it in reading order.
+	var textBoxes []string
+
+	// Headers are inserted where their section starts and footers where it
+	// ends, which is marked by its sectPr.
+	sectionStart := 0
+	var pendingSection *docxSectPr
+	closeSection := func(sect *docxSectPr) error {
+		flushGroup()
+		headers, footers, err := c.sectionHeadersFooters(ctx, sect, rels, pkg)
+		if err != nil {
+			return err
+		}
+		if len(headers) > 0 {
+			headers = append(headers, "<hr/>")
+			paragraphs = append(paragraphs[:sectionStart], append(headers, paragraphs[sectionStart:]...)...)
+		}
+		if len(footers) > 0 {
+			paragraphs = append(paragraphs, "<hr/>")
+			paragraphs = append(paragraphs, footers...)
+		}
+		sectionStart = len(paragraphs)
+		return nil
+	}
+
+loop:
 	for {
 		tok, err := decoder.Token()
 		if err != nil {
@@ -381,8 +506,14 @@ func (c *DocxConverter) documentToHTML(docData []byte, rels map[string]ooxml.Rel
 			local := t.Name.Local
 			switch local {
 			case "p":
+				if err := ctx.Err(); err != nil {
+					return "", err
+				}
 				s.inParagraph = true
 				currentPara.Reset()
+				if tc := cell(); tc != nil && tc.content.Len() > 0 {
+					tc.content.WriteString(" ")
+				}
 				s.bold = false
 				s.italic = false
 				s.underline = false
@@ -403,6 +534,13 @@ func (c *DocxConverter) documentToHTML(docData []byte, rels map[string]ooxml.Rel
 					}
 				}
 
+			case "rStyle":
+				for _, attr := range t.Attr {
+					if attr.Name.Local == "val" {
+						s.runStyleID = attr.Value
+					}
+				}
+
 			case "numPr":
 				s.inList = true
 
@@ -424,6 +562,7 @@ func (c *DocxConverter) documentToHTML(docData []byte, rels map[string]ooxml.Rel
 
 			case "r":
 				s.inRun = true
+				s.runStyleID = ""
 				s.bold = false
 				s.italic = false
 				s.underline = false
@@ -463,18 +602,49 @@ func (c *DocxConverter) documentToHTML(docData []byte, rels map[string]ooxml.Rel
 					s.strike = true
 				}
 
-			case "t":
+			case "t", "delText":
 				s.inText = true
 				textBuf.Reset()
 
+			case "ins", "moveTo", "del", "moveFrom":
+				inserted := local == "ins" || local == "moveTo"
+				switch pkg.trackedChanges {
+				case TrackedChangesInline:
+					rev := docxRevision{inserted: inserted}
+					for _, attr := range t.Attr {
+						switch attr.Name.Local {
+						case "author":
+							rev.author = attr.Value
+						case "date":
+							rev.date = attr.Value
+						}
+					}
+					pkg.revisions = append(pkg.revisions, rev)
+					openRevisions = append(openRevisions, len(pkg.revisions)-1)
+					write(revisionMarker(len(pkg.revisions)-1, true))
+				case TrackedChangesReject:
+					if inserted {
+						_ = decoder.Skip()
+					}
+				default:
+					if !inserted {
+						_ = decoder.Skip()
+					}
+				}
+
 			case "tab":
 				if s.inRun {
-					currentPara.WriteString("\t")
+					write("\t")
 				}
 
 			case "br":
 				if s.inRun {
-					currentPara.WriteString("<br/>")
+					// A line break would end a Markdown table row.
+					if cell() != nil {
+						write(" ")
+					} else {
+						write("<br/>")
+					}
 				}
 
 			case "hyperlink":
@@ -488,16 +658,79 @@ func (c *DocxConverter) documentToHTML(docData []byte, rels map[string]ooxml.Rel
 				}
 
 			case "tbl":
-				s.inTable = true
-				tableRows = nil
+				tables = append(tables, &docxTable{})
 
 			case "tr":
-				s.inTableRow = true
-				currentRow = nil
+				if len(tables) > 0 {
+					tbl := tables[len(tables)-1]
+					tbl.rows = append(tbl.rows, &docxTableRow{})
+				}
+
+			case "tblHeader", "gridBefore", "gridAfter":
+				if len(tables) == 0 {
+					break
+				}
+				row := tables[len(tables)-1].lastRow()
+				if row == nil {
+					break
+				}
+				val := ""
+				for _, attr := range t.Attr {
+					if attr.Name.Local == "val" {
+						val = attr.Value
+					}
+				}
+				limit := tables[len(tables)-1].maxColumns()
+				switch local {
+				case "tblHeader":
+					row.header = ooxmlOn(val)
+				case "gridBefore":
+					row.gridBefore = min(max(ooxmlInt(val), 0), limit)
+				case "gridAfter":
+					row.gridAfter = min(max(ooxmlInt(val), 0), limit)
+				}
 
 			case "tc":
-				s.inTableCell = true
-				cellContent.Reset()
+				tc := &docxTableCell{span: 1}
+				if len(tables) > 0 {
+					if row := tables[len(tables)-1].lastRow(); row != nil {
+						row.cells = append(row.cells, tc)
+					}
+				}
+				cells = append(cells, tc)
+
+			case "gridCol":
+				if len(tables) > 0 {
+					tables[len(tables)-1].gridCols++
+				}
+
+			case "gridSpan":
+				if tc := cell(); tc != nil && len(tables) > 0 {
+					limit := tables[len(tables)-1].maxColumns()
+					for _, attr := range t.Attr {
+						if attr.Name.Local == "val" {
+							tc.span = min(max(ooxmlInt(attr.Value), 1), limit)
+						}
+					}
+				}
+
+			case "vMerge":
+				if tc := cell(); tc != nil {
+					// A bare w:vMerge continues the merge; "restart" begins one.
+					tc.merged = true
+					for _, attr := range t.Attr {
+						if attr.Name.Local == "val" && attr.Value == "restart" {
+							tc.merged = false
+						}
+					}
+				}
+
+			case "footnoteReference", "endnoteReference":
+				for _, attr := range t.Attr {
+					if attr.Name.Local == "id" {
+						write(noteMarker(local, attr.Value))
+					}
+				}
 
 			case "commentReference":
 				for _, attr := range t.Attr {
@@ -507,14 +740,41 @@ func (c *DocxConverter) documentToHTML(docData []byte, rels map[string]ooxml.Rel
 				}
 
 			case "drawing", "pict":
-				// Try to extract images
-				imgData := c.extractImage(t, decoder, zr)
+				// Extract images and text boxes
+				imgData, boxes, err := c.extractDrawing(ctx, decoder, rels, pkg)
+				if err != nil {
+					return "", err
+				}
 				if imgData != "" {
-					if s.inTableCell {
-						cellContent.WriteString(imgData)
-					} else {
-						currentPara.WriteString(imgData)
+					write(imgData)
+				}
+				if cell() != nil {
+					for _, box := range boxes {
+						write(box)
 					}
+				} else {
+					textBoxes = append(textBoxes, boxes...)
+				}
+
+			case "Fallback":
+				// mc:AlternateContent repeats the mc:Choice content (e.g. a
+				// DrawingML text box) in a legacy form (VML); keep only one.
+				_ = decoder.Skip()
+
+			case "sectPr":
+				if !pkg.headersFooters {
+					_ = decoder.Skip()
+					break
+				}
+				var sect docxSectPr
+				if err := decoder.DecodeElement(&sect, &t); err != nil {
+					break
+				}
+				if s.inParagraph {
+					// The section ends with the paragraph holding its sectPr.
+					pendingSection = &sect
+				} else if err := closeSection(&sect); err != nil {
+					return "", err
 				}
 			}
 
@@ -525,8 +785,17 @@ func (c *DocxConverter) documentToHTML(docData []byte, rels map[string]ooxml.Rel
 
 		case xml.EndElement:
 			local := t.Name.Local
+			if until != "" && local == until {
+				break loop
+			}
 			switch local {
-			case "t":
+			case "ins", "moveTo", "del", "moveFrom":
+				if n := len(openRevisions); n > 0 {
+					write(revisionMarker(openRevisions[n-1], false))
+					openRevisions = openRevisions[:n-1]
+				}
+
+			case "t", "delText":
 				if s.inText {
 					text := textBuf.String()
 					text = escapeHTMLText(text)
@@ -541,21 +810,21 @@ func (c *DocxConverter) documentToHTML(docData []byte, rels map[string]ooxml.Rel
 					if s.strike {
 						text = "<s>" + text + "</s>"
 					}
+					if rm := pkg.styleMap.lookup("r", s.runStyleID, styleName(s.runStyleID)); rm != nil {
+						text = rm.wrapInline(text)
+					}
 
-					if s.inHyper && s.hyperRef != "" {
+					if text != "" && s.inHyper && s.hyperRef != "" {
 						text = `<a href="` + escapeHTMLAttr(s.hyperRef) + `">` + text + "</a>"
 					}
 
-					if s.inTableCell {
-						cellContent.WriteString(text)
-					} else {
-						currentPara.WriteString(text)
-					}
+					write(text)
 					s.inText = false
 				}
 
 			case "r":
 				s.inRun = false
+				s.runStyleID = ""
 				s.bold = false
 				s.italic = false
 
@@ -568,73 +837,97 @@ func (c *DocxConverter) documentToHTML(docData []byte, rels map[string]ooxml.Rel
 
 				// Add comment annotations
 				for _, commentID := range commentRefs {
-					if comment, ok := comments[commentID]; ok {
+					if comment, ok := pkg.comments[commentID]; ok {
 						paraText += fmt.Sprintf(" [comment by %s: %s]", comment.author, comment.text)
 					}
 				}
 
-				if s.inTableCell {
-					cellContent.WriteString(paraText)
+				if tc := cell(); tc != nil {
+					tc.content.WriteString(paraText)
+				} else if pm := pkg.styleMap.lookup("p", s.styleID, styleName(s.styleID)); pm != nil {
+					if groupMapping != pm || !pm.canMerge() {
+						flushGroup()
+					}
+					if paraText != "" || pm.canMerge() {
+						groupMapping = pm
+						groupParas = append(groupParas, paraText)
+					}
 				} else {
 					// Determine heading level from style
-					headingLevel := c.getHeadingLevel(s.styleID, styles)
+					headingLevel := c.getHeadingLevel(s.styleID, pkg.styles)
 
-					if headingLevel > 0 {
-						tag := fmt.Sprintf("h%d", headingLevel)
-						paraText = "<" + tag + ">" + paraText + "</" + tag + ">"
-					} else if s.inList && s.listNumID != "0" {
+					switch {
+					case headingLevel == 0 && s.inList && s.listNumID != "0":
 						// List item
-						paraText = "<li>" + paraText + "</li>"
-					} else if paraText != "" {
-						paraText = "<p>" + paraText + "</p>"
+						if groupMapping != nil {
+							flushGroup()
+						}
+						listItems = append(listItems, pkg.listItem(s.listNumID, s.listLevel, paraText))
+					case headingLevel == 0 && paraText == "" && len(listItems) > 0:
+						// Blank paragraphs do not interrupt a list.
+					default:
+						flushGroup()
+						if headingLevel > 0 {
+							tag := fmt.Sprintf("h%d", headingLevel)
+							paraText = "<" + tag + ">" + paraText + "</" + tag + ">"
+						} else if paraText != "" {
+							paraText = "<p>" + paraText + "</p>"
+						}
+						if paraText != "" {
+							paragraphs = append(paragraphs, paraText)
+						}
 					}
-
-					if paraText != "" {
-						paragraphs = append(paragraphs, paraText)
+				}
+				if len(textBoxes) > 0 {
+					flushGroup()
+					paragraphs = append(paragraphs, textBoxes...)
+					textBoxes = nil
+				}
+				if pendingSection != nil {
+					if err := closeSection(pendingSection); err != nil {
+						return "", err
 					}
+					pendingSection = nil
 				}
 				s.inParagraph = false
 				s.styleID = ""
 
 			case "tc":
-				currentRow = append(currentRow, cellContent.String())
-				s.inTableCell = false
-
-			case "tr":
-				tableRows = append(tableRows, currentRow)
-				s.inTableRow = false
+				if len(cells) > 0 {
+					cells = cells[:len(cells)-1]
+				}
 
 			case "tbl":
-				// Render table as HTML
-				if len(tableRows) > 0 {
-					var tableBuf strings.Builder
-					tableBuf.WriteString("<table>")
-					for i, row := range tableRows {
-						tableBuf.WriteString("<tr>")
-						tag := "td"
-						if i == 0 {
-							tag = "th"
-						}
-						for _, cell := range row {
-							tableBuf.WriteString("<" + tag + ">" + cell + "</" + tag + ">")
-						}
-						tableBuf.WriteString("</tr>")
+				if len(tables) == 0 {
+					break
+				}
+				tbl := tables[len(tables)-1]
+				tables = tables[:len(tables)-1]
+				if tc := cell(); tc != nil {
+					// Nested table
+					if tc.content.Len() > 0 {
+						tc.content.WriteString(" ")
+					}
+					tc.content.WriteString(tbl.flatten())
+				} else {
+					// The grid is padded with empty cells the document does
+					// not contain; they count against the budget.
+					if err := stateFrom(ctx).chargeOutput(int64(len(tbl.rows) * tbl.columns())); err != nil {
+						return "", err
+					}
+					if html := tbl.html(); html != "" {
+						flushGroup()
+						paragraphs = append(paragraphs, html)
 					}
-					tableBuf.WriteString("</table>")
-					paragraphs = append(paragraphs, tableBuf.String())
 				}
-				s.inTable = false
 			}
 		}
 	}
 
-	for _, p := range paragraphs {
-		html.WriteString(p)
-		html.WriteString("\n")
-	}
+	flushGroup()
+	paragraphs = append(paragraphs, textBoxes...)
 
-	html.WriteString("</body></html>")
-	return html.String()
+	return strings.Join(paragraphs, "\n"), nil
 }
 
 // getHeadingLevel returns the heading level (1-6) for a style, or 0 if not a heading.
@@ -672,13 +965,15 @@ func (c *DocxConverter) getHeadingLevel(styleID string, styles map[string]styleI
 	return 0
 }
 
-// extractImage attempts to extract an image from a drawing or pict element.
-func (c *DocxConverter) extractImage(startElem xml.StartElement, decoder *xml.Decoder, zr *zip.Reader) string {
+// extractDrawing consumes a drawing or pict element, returning its image as
+// HTML and the HTML of any text boxes (text-bearing shapes) it contains.
+func (c *DocxConverter) extractDrawing(ctx context.Context, decoder *xml.Decoder, rels map[string]ooxml.Relationship, pkg *docxPackage) (string, []string, error) {
 	// We need to consume the entire drawing/pict element
 	// and look for embedded image references
 	depth := 1
 	var embedID string
 	var altText string
+	var boxes []string
 
 	for depth > 0 {
 		tok, err := decoder.Token()
@@ -687,6 +982,21 @@ func (c *DocxConverter) extractImage(startElem xml.StartElement, decoder *xml.De
 		}
 		switch t := tok.(type) {
 		case xml.StartElement:
+			switch t.Name.Local {
+			case "txbxContent":
+				// Converted recursively; the call consumes the end element.
+				box, err := c.blocksToHTML(ctx, decoder, rels, pkg, "txbxContent")
+				if err != nil {
+					return "", nil, err
+				}
+				if strings.TrimSpace(box) != "" {
+					boxes = append(boxes, box)
+				}
+				continue
+			case "Fallback":
+				_ = decoder.Skip()
+				continue
+			}
 			depth++
 			// Look for blip element (embedded image reference)
 			if t.Name.Local == "blip" {
@@ -710,11 +1020,14 @@ func (c *DocxConverter) extractImage(startElem xml.StartElement, decoder *xml.De
 	}
 
 	if embedID == "" {
-		return ""
+		return "", boxes, nil
 	}
+	return c.imageHTML(pkg.zr, rels, embedID, altText), boxes, nil
+}
 
+// imageHTML returns an img element for the image behind relationship embedID.
+func (c *DocxConverter) imageHTML(zr *zip.Reader, rels map[string]ooxml.Relationship, embedID, altText string) string {
 	// Resolve the relationship to find the image file
-	rels, _ := ooxml.ParseRelationshipsFromReader(zr, "word/_rels/document.xml.rels")
 	rel, ok := rels[embedID]
 	if !ok {
 		return ""
@@ -732,20 +1045,13 @@ func (c *DocxConverter) extractImage(startElem xml.StartElement, decoder *xml.De
 	}
 
 	// Determine content type from extension
-	ext := strings.ToLower(path.Ext(rel.Target))
-	contentType := "image/png"
-	switch ext {
-	case ".jpg", ".jpeg":
-		contentType = "image/jpeg"
-	case ".gif":
-		contentType = "image/gif"
-	case ".bmp":
-		contentType = "image/bmp"
-	case ".svg":
-		contentType = "image/svg+xml"
-	}
-
-	// Encode as data URI
+	contentType := imageMediaType(rel.Target)
+	if contentType == "" {
+		contentType = "image/png"
+	}
+
+	// Encode as data URI; with an asset writer the image is stored when the
+	// HTML is converted to Markdown
 	b64 := base64.StdEncoding.EncodeToString(imgData)
 	src := fmt.Sprintf("data:%s;base64,%s", contentType, b64)
 
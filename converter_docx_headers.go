@@ -0,0 +1,77 @@
+// Copyright 2026 Conductor OSS
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+
+package markitdown
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"strings"
+
+	"github.com/conductor-oss/markitdown/internal/ooxml"
+)
+
+// docxSectPr holds the header and footer references of a section.
+type docxSectPr struct {
+	Headers []docxHeaderRef `xml:"headerReference"`
+	Footers []docxHeaderRef `xml:"footerReference"`
+}
+
+// docxHeaderRef references a header or footer part. Type is "default",
+// "first" or "even".
+type docxHeaderRef struct {
+	Type string `xml:"type,attr"`
+	ID   string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+}
+
+// sectionHeadersFooters converts the headers and footers referenced by a
+// section. Parts whose content was already emitted for an earlier section
+// (or as another header type) are skipped, so repeated headers appear once.
+func (c *DocxConverter) sectionHeadersFooters(ctx context.Context, sect *docxSectPr, rels map[string]ooxml.Relationship, pkg *docxPackage) (headers, footers []string, err error) {
+	convert := func(refs []docxHeaderRef) ([]string, error) {
+		var out []string
+		for _, ref := range refs {
+			rel, ok := rels[ref.ID]
+			if !ok {
+				continue
+			}
+			partName := ooxml.ResolveTarget("word/document.xml", rel.Target)
+			data, err := ooxml.ReadFileFromZip(pkg.zr, partName)
+			if err != nil {
+				continue
+			}
+			partRels, _ := ooxml.ParseRelationshipsFromReader(pkg.zr, ooxml.RelsPathFor(partName))
+			html, err := c.blocksToHTML(ctx, xml.NewDecoder(bytes.NewReader(data)), partRels, pkg, "")
+			if err != nil {
+				return nil, err
+			}
+			key := strings.TrimSpace(html)
+			if key == "" || pkg.seenHeadersFooters[key] {
+				continue
+			}
+			if pkg.seenHeadersFooters == nil {
+				pkg.seenHeadersFooters = map[string]bool{}
+			}
+			pkg.seenHeadersFooters[key] = true
+			out = append(out, html)
+		}
+		return out, nil
+	}
+
+	if headers, err = convert(sect.Headers); err != nil {
+		return nil, nil, err
+	}
+	if footers, err = convert(sect.Footers); err != nil {
+		return nil, nil, err
+	}
+	return headers, footers, nil
+}
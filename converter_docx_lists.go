@@ -0,0 +1,240 @@
+// Copyright 2026 Conductor OSS
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+
+package markitdown
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"strconv"
+	"strings"
+
+	"github.com/conductor-oss/markitdown/internal/ooxml"
+)
+
+// maxListLevel is the deepest w:ilvl Word supports (levels 0-8).
+const maxListLevel = 8
+
+// numberingDef is a numbering instance (w:num) resolved against its abstract
+// numbering definition, with any level overrides applied.
+type numberingDef struct {
+	abstractNumID string
+	levels        map[int]numberingLevel
+	counter       string // key of the counters numbering its items
+}
+
+// numberingLevel is the format of one list level.
+type numberingLevel struct {
+	format string // w:numFmt: "decimal", "bullet", "lowerLetter", "upperRoman", ...
+	start  int
+}
+
+// level returns the format of level ilvl. Unknown levels are bulleted.
+func (d numberingDef) level(ilvl int) numberingLevel {
+	if lvl, ok := d.levels[ilvl]; ok {
+		return lvl
+	}
+	return numberingLevel{format: "bullet", start: 1}
+}
+
+// ordered reports whether the level is numbered rather than bulleted.
+// Markdown only has numeric ordered lists, so letters and roman numerals are
+// rendered as numbers.
+func (l numberingLevel) ordered() bool {
+	switch l.format {
+	case "", "bullet", "none":
+		return false
+	}
+	return true
+}
+
+type docxVal struct {
+	Val string `xml:"val,attr"`
+}
+
+type docxLvl struct {
+	Level  string   `xml:"ilvl,attr"`
+	Start  *docxVal `xml:"start"`
+	NumFmt *docxVal `xml:"numFmt"`
+}
+
+type docxNumbering struct {
+	AbstractNums []struct {
+		ID     string    `xml:"abstractNumId,attr"`
+		Levels []docxLvl `xml:"lvl"`
+	} `xml:"abstractNum"`
+	Nums []struct {
+		ID            string  `xml:"numId,attr"`
+		AbstractNumID docxVal `xml:"abstractNumId"`
+		Overrides     []struct {
+			Level         string   `xml:"ilvl,attr"`
+			StartOverride *docxVal `xml:"startOverride"`
+			Lvl           *docxLvl `xml:"lvl"`
+		} `xml:"lvlOverride"`
+	} `xml:"num"`
+}
+
+// apply merges the properties set on lvl into level.
+func (lvl docxLvl) apply(level numberingLevel) numberingLevel {
+	if lvl.Start != nil {
+		level.start = ooxmlInt(lvl.Start.Val)
+	}
+	if lvl.NumFmt != nil {
+		level.format = lvl.NumFmt.Val
+	}
+	return level
+}
+
+// parseNumbering reads word/numbering.xml into numbering instances keyed by
+// w:numId.
+func (c *DocxConverter) parseNumbering(zr *zip.Reader) map[string]numberingDef {
+	numbering := make(map[string]numberingDef)
+	data, err := ooxml.ReadFileFromZip(zr, "word/numbering.xml")
+	if err != nil {
+		return numbering
+	}
+	var doc docxNumbering
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return numbering
+	}
+
+	abstract := make(map[string]map[int]numberingLevel)
+	for _, an := range doc.AbstractNums {
+		levels := make(map[int]numberingLevel)
+		for _, lvl := range an.Levels {
+			// Word always writes w:start; default to 1 when it is missing.
+			levels[ooxmlInt(lvl.Level)] = lvl.apply(numberingLevel{start: 1})
+		}
+		abstract[an.ID] = levels
+	}
+
+	for _, num := range doc.Nums {
+		def := numberingDef{abstractNumID: num.AbstractNumID.Val, levels: make(map[int]numberingLevel)}
+		for ilvl, lvl := range abstract[def.abstractNumID] {
+			def.levels[ilvl] = lvl
+		}
+		// Instances of an abstract numbering share its counters, unless
+		// they restart the numbering.
+		def.counter = "abstract:" + def.abstractNumID
+		for _, o := range num.Overrides {
+			ilvl := ooxmlInt(o.Level)
+			level := def.level(ilvl)
+			if o.Lvl != nil {
+				level = o.Lvl.apply(level)
+				if o.Lvl.Start != nil {
+					def.counter = "num:" + num.ID
+				}
+			}
+			if o.StartOverride != nil {
+				level.start = ooxmlInt(o.StartOverride.Val)
+				def.counter = "num:" + num.ID
+			}
+			def.levels[ilvl] = level
+		}
+		numbering[num.ID] = def
+	}
+	return numbering
+}
+
+// docxListItem is a list paragraph waiting to be rendered with the rest of
+// its list.
+type docxListItem struct {
+	level   int
+	ordered bool
+	number  int
+	html    string
+}
+
+// listItem numbers a list paragraph of numbering instance numID at level
+// ilvl. Counters run across the whole document and are shared by the
+// instances of an abstract numbering that do not restart it, so a list
+// interrupted by other paragraphs continues where it left off. Starting an
+// item resets the counters of the levels below it.
+func (pkg *docxPackage) listItem(numID string, ilvl int, html string) docxListItem {
+	ilvl = min(max(ilvl, 0), maxListLevel)
+	def := pkg.numbering[numID]
+	level := def.level(ilvl)
+	key := def.counter
+	if key == "" {
+		key = "num:" + numID
+	}
+
+	if pkg.listCounters == nil {
+		pkg.listCounters = make(map[string]map[int]int)
+	}
+	counters := pkg.listCounters[key]
+	if counters == nil {
+		counters = make(map[int]int)
+		pkg.listCounters[key] = counters
+	}
+	if n, ok := counters[ilvl]; ok {
+		counters[ilvl] = n + 1
+	} else {
+		counters[ilvl] = level.start
+	}
+	for l := range counters {
+		if l > ilvl {
+			delete(counters, l)
+		}
+	}
+	return docxListItem{level: ilvl, ordered: level.ordered(), number: counters[ilvl], html: html}
+}
+
+// renderList renders consecutive list items as nested <ol>/<ul> lists. A new
+// list starts where the kind of list changes or the numbering restarts, and
+// ordered lists that do not start at 1 carry a start attribute.
+func renderList(items []docxListItem) string {
+	type openList struct {
+		level   int
+		ordered bool
+		next    int
+	}
+	var stack []openList
+	var buf strings.Builder
+	closeList := func() {
+		tag := "ul"
+		if stack[len(stack)-1].ordered {
+			tag = "ol"
+		}
+		buf.WriteString("</li></" + tag + ">")
+		stack = stack[:len(stack)-1]
+	}
+
+	for _, item := range items {
+		for len(stack) > 0 && stack[len(stack)-1].level > item.level {
+			closeList()
+		}
+		if n := len(stack); n > 0 && stack[n-1].level == item.level {
+			top := &stack[n-1]
+			if top.ordered == item.ordered && (!item.ordered || item.number == top.next) {
+				top.next = item.number + 1
+				buf.WriteString("</li><li>" + item.html)
+				continue
+			}
+			closeList()
+		}
+		// Open a new list, nested in the enclosing item if there is one.
+		switch {
+		case !item.ordered:
+			buf.WriteString("<ul>")
+		case item.number != 1:
+			buf.WriteString(`<ol start="` + strconv.Itoa(item.number) + `">`)
+		default:
+			buf.WriteString("<ol>")
+		}
+		buf.WriteString("<li>" + item.html)
+		stack = append(stack, openList{level: item.level, ordered: item.ordered, next: item.number + 1})
+	}
+	for len(stack) > 0 {
+		closeList()
+	}
+	return buf.String()
+}
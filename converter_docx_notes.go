@@ -0,0 +1,171 @@
+// Copyright 2026 Conductor OSS
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+
+package markitdown
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/conductor-oss/markitdown/internal/ooxml"
+)
+
+// Footnote and endnote references (and inline revisions, see
+// converter_docx_revisions.go) are written into the intermediate HTML as
+// private-use markers, which survive the HTML to markdown conversion
+// unescaped, and are replaced by markdown syntax afterwards.
+const (
+	noteMarkerStart = "\uE000"
+	noteMarkerEnd   = "\uE001"
+)
+
+var reNoteMarker = regexp.MustCompile(noteMarkerStart + `([fe])(-?\d+)` + noteMarkerEnd)
+
+// noteMarker returns the marker for a footnoteReference or endnoteReference.
+func noteMarker(element, id string) string {
+	kind := "f"
+	if element == "endnoteReference" {
+		kind = "e"
+	}
+	return noteMarkerStart + kind + id + noteMarkerEnd
+}
+
+// docxNotes holds the notes of one part (footnotes.xml or endnotes.xml),
+// each as a standalone XML fragment, plus the part's relationships.
+type docxNotes struct {
+	notes map[string][]byte
+	rels  map[string]ooxml.Relationship
+}
+
+// parseNotes reads word/footnotes.xml or word/endnotes.xml. element is the
+// note element name ("footnote" or "endnote").
+func (c *DocxConverter) parseNotes(pkg *docxPackage, partName, element string) docxNotes {
+	notes := docxNotes{notes: map[string][]byte{}}
+	data, err := ooxml.ReadFileFromZip(pkg.zr, partName)
+	if err != nil {
+		return notes
+	}
+	notes.rels, _ = ooxml.ParseRelationshipsFromReader(pkg.zr, ooxml.RelsPathFor(partName))
+
+	// Each note is re-wrapped in the part's root start tag so that its
+	// namespace declarations (needed to resolve r:id) stay in scope.
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	var rootOpen []byte
+	var rootClose string
+	var noteID string
+	var noteStart int64
+	depth := 0
+	for {
+		offset := decoder.InputOffset()
+		tok, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			depth++
+			switch {
+			case depth == 1:
+				rootOpen = data[offset:decoder.InputOffset()]
+				name := bytes.TrimPrefix(rootOpen, []byte("<"))
+				if i := bytes.IndexAny(name, " \t\r\n/>"); i >= 0 {
+					name = name[:i]
+				}
+				rootClose = "</" + string(name) + ">"
+			case depth == 2 && t.Name.Local == element:
+				noteID = ""
+				for _, attr := range t.Attr {
+					if attr.Name.Local == "id" {
+						noteID = attr.Value
+					}
+				}
+				noteStart = decoder.InputOffset()
+			}
+		case xml.EndElement:
+			if depth == 2 && t.Name.Local == element && noteID != "" {
+				var b bytes.Buffer
+				b.Write(rootOpen)
+				b.Write(data[noteStart:offset])
+				b.WriteString(rootClose)
+				notes.notes[noteID] = b.Bytes()
+			}
+			depth--
+		}
+	}
+	return notes
+}
+
+// renderNotes replaces note markers in md with GFM footnote references,
+// numbered in order of first reference, and appends the note definitions.
+// Notes keep their formatting and hyperlinks; notes nested inside notes are
+// not supported and their markers are dropped.
+func (c *DocxConverter) renderNotes(ctx context.Context, md string, pkg *docxPackage) (string, error) {
+	if !strings.Contains(md, noteMarkerStart) {
+		return md, nil
+	}
+
+	parts := map[string]docxNotes{
+		"f": c.parseNotes(pkg, "word/footnotes.xml", "footnote"),
+		"e": c.parseNotes(pkg, "word/endnotes.xml", "endnote"),
+	}
+
+	type noteRef struct{ kind, id string }
+	labels := map[noteRef]int{}
+	var order []noteRef
+	md = reNoteMarker.ReplaceAllStringFunc(md, func(marker string) string {
+		m := reNoteMarker.FindStringSubmatch(marker)
+		ref := noteRef{m[1], m[2]}
+		if _, ok := parts[ref.kind].notes[ref.id]; !ok {
+			return ""
+		}
+		label, ok := labels[ref]
+		if !ok {
+			order = append(order, ref)
+			label = len(order)
+			labels[ref] = label
+		}
+		return fmt.Sprintf("[^%d]", label)
+	})
+	if len(order) == 0 {
+		return md, nil
+	}
+
+	htmlConv := NewHTMLConverter(c.markitdown)
+	var defs strings.Builder
+	for i, ref := range order {
+		part := parts[ref.kind]
+		noteHTML, err := c.documentToHTML(ctx, part.notes[ref.id], part.rels, pkg)
+		if err != nil {
+			return "", err
+		}
+		note, err := htmlConv.convertString(ctx, noteHTML)
+		if err != nil {
+			return "", fmt.Errorf("convert note %s: %w", ref.id, err)
+		}
+		body := strings.TrimSpace(reNoteMarker.ReplaceAllString(note.Markdown, ""))
+
+		// Continuation lines of multi-paragraph notes are indented so that
+		// they stay part of the definition.
+		lines := strings.Split(body, "\n")
+		for j := 1; j < len(lines); j++ {
+			if lines[j] != "" {
+				lines[j] = "    " + lines[j]
+			}
+		}
+		fmt.Fprintf(&defs, "[^%d]: %s\n", i+1, strings.Join(lines, "\n"))
+	}
+
+	return strings.TrimRight(md, "\n") + "\n\n" + defs.String(), nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 Conductor OSS
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+
+package markitdown
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// docxRevision is a tracked insertion or deletion shown inline.
+type docxRevision struct {
+	inserted bool
+	author   string
+	date     string
+}
+
+var reRevisionMarker = regexp.MustCompile(noteMarkerStart + `([oc])(\d+)` + noteMarkerEnd)
+
+// revisionMarker returns the marker opening or closing revision i.
+func revisionMarker(i int, open bool) string {
+	kind := "c"
+	if open {
+		kind = "o"
+	}
+	return noteMarkerStart + kind + strconv.Itoa(i) + noteMarkerEnd
+}
+
+// renderRevisions replaces revision markers with CriticMarkup. Revisions
+// without visible content, such as a deleted paragraph mark, are dropped.
+func renderRevisions(md string, revisions []docxRevision) string {
+	if len(revisions) == 0 {
+		return md
+	}
+	for i := range revisions {
+		md = strings.ReplaceAll(md, revisionMarker(i, true)+revisionMarker(i, false), "")
+	}
+	return reRevisionMarker.ReplaceAllStringFunc(md, func(marker string) string {
+		m := reRevisionMarker.FindStringSubmatch(marker)
+		i, _ := strconv.Atoi(m[2])
+		rev := revisions[i]
+		sign := "--"
+		if rev.inserted {
+			sign = "++"
+		}
+		if m[1] == "o" {
+			return "{" + sign
+		}
+		var who []string
+		if rev.author != "" {
+			who = append(who, rev.author)
+		}
+		if rev.date != "" {
+			who = append(who, rev.date)
+		}
+		if len(who) == 0 {
+			return sign + "}"
+		}
+		return sign + "}{>>" + strings.Join(who, ", ") + "<<}"
+	})
+}
@@ -0,0 +1,182 @@
+// Copyright 2026 Conductor OSS
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+
+package markitdown
+
+import (
+	"strconv"
+	"strings"
+)
+
+// docxMaxColumns is the most columns a table may have in Word, used to bound
+// the spans of tables without a w:tblGrid.
+const docxMaxColumns = 63
+
+// docxTable collects the rows of a w:tbl while it is being read.
+type docxTable struct {
+	rows     []*docxTableRow
+	gridCols int // w:gridCol elements of the w:tblGrid
+}
+
+type docxTableRow struct {
+	cells      []*docxTableCell
+	header     bool // w:tblHeader: repeated as a header row on each page
+	gridBefore int  // grid columns skipped before the first cell
+	gridAfter  int  // grid columns left empty after the last cell
+}
+
+type docxTableCell struct {
+	content strings.Builder
+	span    int  // w:gridSpan
+	merged  bool // w:vMerge continuation of the cell above
+}
+
+// lastRow returns the row being read, or nil before the first w:tr.
+func (t *docxTable) lastRow() *docxTableRow {
+	if len(t.rows) == 0 {
+		return nil
+	}
+	return t.rows[len(t.rows)-1]
+}
+
+// maxColumns returns the number of grid columns the table may span: its
+// w:tblGrid column count, at most docxMaxColumns. Spans read from the
+// document are clamped to it, since a forged span would otherwise be padded
+// out with millions of empty cells.
+func (t *docxTable) maxColumns() int {
+	if t.gridCols > 0 {
+		return min(t.gridCols, docxMaxColumns)
+	}
+	return docxMaxColumns
+}
+
+// columns returns the width of the rendered table: the widest row, at most
+// maxColumns.
+func (t *docxTable) columns() int {
+	columns := 0
+	for _, row := range t.rows {
+		columns = max(columns, row.width())
+	}
+	return min(columns, t.maxColumns())
+}
+
+// ooxmlInt parses a w:val attribute, returning 0 if it is not a number.
+func ooxmlInt(val string) int {
+	n, _ := strconv.Atoi(val)
+	return n
+}
+
+// ooxmlOn reports whether an on/off property such as w:tblHeader is set. The
+// property is on when present unless its w:val says otherwise.
+func ooxmlOn(val string) bool {
+	switch val {
+	case "0", "false", "off":
+		return false
+	}
+	return true
+}
+
+// width returns the number of grid columns the row spans.
+func (r *docxTableRow) width() int {
+	n := r.gridBefore + r.gridAfter
+	for _, cell := range r.cells {
+		n += max(cell.span, 1)
+	}
+	return n
+}
+
+// gridCells returns the row's cell contents laid out on the table grid:
+// horizontally merged cells keep their content in the first column they
+// cover, and vertically merged continuation cells are left empty, so every
+// cell lines up with its column. Empty cells are only added while they fit
+// beside the row's cells within columns.
+func (r *docxTableRow) gridCells(columns int) []string {
+	out := make([]string, 0, columns)
+	free := columns - len(r.cells)
+	pad := func(n int) {
+		for ; n > 0 && free > 0; n-- {
+			out = append(out, "")
+			free--
+		}
+	}
+	pad(r.gridBefore)
+	for _, cell := range r.cells {
+		content := strings.TrimSpace(cell.content.String())
+		if cell.merged {
+			content = ""
+		}
+		out = append(out, content)
+		pad(cell.span - 1)
+	}
+	for len(out) < columns {
+		out = append(out, "")
+	}
+	return out
+}
+
+// html renders the table. Leading w:tblHeader rows become the header; without
+// them the first row is used, as Markdown tables always have one.
+func (t *docxTable) html() string {
+	if len(t.rows) == 0 {
+		return ""
+	}
+	columns := t.columns()
+	headerRows := 0
+	for headerRows < len(t.rows) && t.rows[headerRows].header {
+		headerRows++
+	}
+	if headerRows == 0 {
+		headerRows = 1
+	}
+
+	var buf strings.Builder
+	buf.WriteString("<table><thead>")
+	for i, row := range t.rows {
+		if i == headerRows {
+			buf.WriteString("</thead><tbody>")
+		}
+		tag := "td"
+		if i < headerRows {
+			tag = "th"
+		}
+		buf.WriteString("<tr>")
+		for _, cell := range row.gridCells(columns) {
+			buf.WriteString("<" + tag + ">" + cell + "</" + tag + ">")
+		}
+		buf.WriteString("</tr>")
+	}
+	if headerRows < len(t.rows) {
+		buf.WriteString("</tbody>")
+	} else {
+		buf.WriteString("</thead>")
+	}
+	buf.WriteString("</table>")
+	return buf.String()
+}
+
+// flatten renders a table nested in another table's cell as inline text,
+// since Markdown table cells cannot hold tables: cells are separated by " / "
+// and rows by "; ".
+func (t *docxTable) flatten() string {
+	var rows []string
+	for _, row := range t.rows {
+		var cells []string
+		for _, cell := range row.cells {
+			if content := strings.TrimSpace(cell.content.String()); content != "" && !cell.merged {
+				cells = append(cells, content)
+			}
+		}
+		if len(cells) > 0 {
+			rows = append(rows, strings.Join(cells, " / "))
+		}
+	}
+	return strings.Join(rows, "; ")
+}
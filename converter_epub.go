@@ -14,9 +14,11 @@ package markitdown
 import (
 	"archive/zip"
 	"bytes"
+	"context"
 	"encoding/xml"
 	"fmt"
 	"io"
+	"net/url"
 	"path"
 	"strings"
 
@@ -43,7 +45,16 @@ func (c *EpubConverter) Accepts(info StreamInfo) bool {
 		strings.HasPrefix(mime, "application/x-epub+zip")
 }
 
+func (c *EpubConverter) Formats() (extensions, mimeTypes []string) {
+	return []string{".epub"}, []string{"application/epub+zip"}
+}
+
 func (c *EpubConverter) Convert(reader io.ReadSeeker, info StreamInfo) (*DocumentConverterResult, error) {
+	return c.ConvertContext(context.Background(), reader, info)
+}
+
+// ConvertContext converts the EPUB spine, checking ctx between chapters.
+func (c *EpubConverter) ConvertContext(ctx context.Context, reader io.ReadSeeker, info StreamInfo) (*DocumentConverterResult, error) {
 	data, err := io.ReadAll(reader)
 	if err != nil {
 		return nil, fmt.Errorf("read EPUB: %w", err)
@@ -53,6 +64,9 @@ func (c *EpubConverter) Convert(reader io.ReadSeeker, info StreamInfo) (*Documen
 	if err != nil {
 		return nil, fmt.Errorf("open EPUB ZIP: %w", err)
 	}
+	if err := checkArchive(ctx, zr); err != nil {
+		return nil, err
+	}
 
 	// Find OPF file path from container.xml
 	opfPath, err := c.findOPFPath(zr)
@@ -88,13 +102,23 @@ func (c *EpubConverter) Convert(reader io.ReadSeeker, info StreamInfo) (*Documen
 		md.WriteString(fmt.Sprintf("**Description:** %s\n\n", metadata.description))
 	}
 
+	result := &DocumentConverterResult{Title: metadata.title}
+	result.setMetadata(MetadataTitle, metadata.title)
+	result.setMetadata(MetadataAuthor, strings.Join(metadata.authors, ", "))
+	result.setMetadata(MetadataLanguage, metadata.language)
+	result.setMetadata(MetadataCreated, metadata.date)
+
 	// Process spine items in reading order
 	opfDir := path.Dir(opfPath)
 	htmlConv := NewHTMLConverter(c.markitdown)
 
-	for _, itemRef := range spine {
+	for i, itemRef := range spine {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		item, ok := manifest[itemRef]
 		if !ok {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("spine item %q not found in manifest", itemRef))
 			continue
 		}
 
@@ -107,6 +131,7 @@ func (c *EpubConverter) Convert(reader io.ReadSeeker, info StreamInfo) (*Documen
 		// Read file from ZIP
 		fileData, err := ooxml.ReadFileFromZip(zr, filePath)
 		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("chapter %s skipped: %v", filePath, err))
 			continue
 		}
 
@@ -116,18 +141,48 @@ func (c *EpubConverter) Convert(reader io.ReadSeeker, info StreamInfo) (*Documen
 			strings.Contains(item.mediaType, "html") || strings.Contains(item.mediaType, "xhtml")
 
 		if isHTML {
-			result, err := htmlConv.ConvertString(string(fileData))
-			if err == nil && strings.TrimSpace(result.Markdown) != "" {
-				md.WriteString(result.Markdown)
+			chapter, err := htmlConv.convertString(ctx, string(fileData))
+			if err != nil {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("chapter %s skipped: %v", filePath, err))
+				continue
+			}
+			if c.markitdown != nil {
+				chapter.Markdown, err = c.markitdown.processImages(ctx, chapter.Markdown, epubImageOpener(zr, filePath))
+				if err != nil {
+					return nil, err
+				}
+			}
+			if strings.TrimSpace(chapter.Markdown) != "" {
+				md.WriteString(chapter.Markdown)
 				md.WriteString("\n\n")
+				result.Segments = append(result.Segments, Segment{
+					Kind:     SegmentChapter,
+					Number:   i + 1,
+					Name:     filePath,
+					Markdown: chapter.Markdown,
+				})
 			}
 		}
 	}
 
-	return &DocumentConverterResult{
-		Markdown: md.String(),
-		Title:    metadata.title,
-	}, nil
+	result.Markdown = md.String()
+	return result, nil
+}
+
+// epubImageOpener returns a function reading the images a chapter links to
+// from the EPUB container. Links are relative to the chapter.
+func epubImageOpener(zr *zip.Reader, chapterPath string) func(target string) ([]byte, bool) {
+	return func(target string) ([]byte, bool) {
+		if strings.Contains(target, ":") || imageMediaType(target) == "" {
+			return nil, false // absolute URL, or not an image
+		}
+		target, _, _ = strings.Cut(target, "#")
+		if unescaped, err := url.PathUnescape(target); err == nil {
+			target = unescaped
+		}
+		data, err := ooxml.ReadFileFromZip(zr, ooxml.ResolveTarget(chapterPath, target))
+		return data, err == nil
+	}
 }
 
 type epubMetadata struct {
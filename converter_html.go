@@ -12,6 +12,7 @@
 package markitdown
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"regexp"
@@ -43,7 +44,17 @@ func (c *HTMLConverter) Accepts(info StreamInfo) bool {
 	return strings.HasPrefix(mime, "text/html") || strings.HasPrefix(mime, "application/xhtml")
 }
 
+func (c *HTMLConverter) Formats() (extensions, mimeTypes []string) {
+	return []string{".html", ".htm"}, []string{"text/html", "application/xhtml+xml"}
+}
+
 func (c *HTMLConverter) Convert(reader io.ReadSeeker, info StreamInfo) (*DocumentConverterResult, error) {
+	return c.ConvertContext(context.Background(), reader, info)
+}
+
+// ConvertContext converts an HTML document. ctx is passed to the asset
+// writer, if any.
+func (c *HTMLConverter) ConvertContext(ctx context.Context, reader io.ReadSeeker, info StreamInfo) (*DocumentConverterResult, error) {
 	data, err := io.ReadAll(reader)
 	if err != nil {
 		return nil, fmt.Errorf("read input: %w", err)
@@ -51,7 +62,7 @@ func (c *HTMLConverter) Convert(reader io.ReadSeeker, info StreamInfo) (*Documen
 
 	htmlStr := string(data)
 
-	result, err := c.ConvertString(htmlStr)
+	result, err := c.convertString(ctx, htmlStr)
 	if err != nil {
 		return nil, err
 	}
@@ -61,6 +72,10 @@ func (c *HTMLConverter) Convert(reader io.ReadSeeker, info StreamInfo) (*Documen
 
 // ConvertString converts an HTML string to markdown.
 func (c *HTMLConverter) ConvertString(htmlStr string) (*DocumentConverterResult, error) {
+	return c.convertString(context.Background(), htmlStr)
+}
+
+func (c *HTMLConverter) convertString(ctx context.Context, htmlStr string) (*DocumentConverterResult, error) {
 	// Extract title from HTML
 	title := extractHTMLTitle(htmlStr)
 
@@ -73,6 +88,13 @@ func (c *HTMLConverter) ConvertString(htmlStr string) (*DocumentConverterResult,
 		return nil, fmt.Errorf("convert HTML to markdown: %w", err)
 	}
 
+	// Describe and store data URI images, if configured
+	if c.markitdown != nil {
+		if md, err = c.markitdown.processImages(ctx, md, nil); err != nil {
+			return nil, err
+		}
+	}
+
 	// Post-process: truncate data URIs unless configured to keep them
 	keepDataURIs := false
 	if c.markitdown != nil {
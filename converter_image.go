@@ -0,0 +1,81 @@
+// Copyright 2026 Conductor OSS
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+
+package markitdown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ImageConverter extracts text from PNG, JPEG and TIFF images with the OCR
+// engine set by WithOCR. It is only registered when an engine is set.
+type ImageConverter struct {
+	markitdown *MarkItDown
+}
+
+// NewImageConverter creates a new ImageConverter.
+func NewImageConverter(m *MarkItDown) *ImageConverter {
+	return &ImageConverter{markitdown: m}
+}
+
+var imageConverterMIMETypes = []string{"image/png", "image/jpeg", "image/tiff"}
+
+func (c *ImageConverter) Accepts(info StreamInfo) bool {
+	switch info.Extension {
+	case ".png", ".jpg", ".jpeg", ".tif", ".tiff":
+		return true
+	}
+	mime := strings.ToLower(info.MIMEType)
+	for _, t := range imageConverterMIMETypes {
+		if strings.HasPrefix(mime, t) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *ImageConverter) Formats() (extensions, mimeTypes []string) {
+	return []string{".png", ".jpg", ".jpeg", ".tif", ".tiff"}, imageConverterMIMETypes
+}
+
+func (c *ImageConverter) Convert(reader io.ReadSeeker, info StreamInfo) (*DocumentConverterResult, error) {
+	return c.ConvertContext(context.Background(), reader, info)
+}
+
+// ConvertContext passes the image to the OCR engine.
+func (c *ImageConverter) ConvertContext(ctx context.Context, reader io.ReadSeeker, info StreamInfo) (*DocumentConverterResult, error) {
+	if c.markitdown == nil || c.markitdown.ocr == nil {
+		return nil, errors.New("no OCR engine configured")
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("read image: %w", err)
+	}
+
+	mediaType := imageMediaType(info.Extension)
+	if mime := strings.ToLower(info.MIMEType); mediaType == "" && strings.HasPrefix(mime, "image/") {
+		mediaType, _, _ = strings.Cut(mime, ";")
+	}
+	text, err := c.markitdown.ocr.Recognize(ctx, data, mediaType)
+	if err != nil {
+		return nil, fmt.Errorf("OCR: %w", err)
+	}
+
+	result := &DocumentConverterResult{Markdown: strings.TrimSpace(text)}
+	if result.Markdown == "" {
+		result.Markdown = "[No readable text content found in image]"
+	}
+	return result, nil
+}
@@ -30,6 +30,10 @@ func (c *IpynbConverter) Accepts(info StreamInfo) bool {
 	return info.Extension == ".ipynb"
 }
 
+func (c *IpynbConverter) Formats() (extensions, mimeTypes []string) {
+	return []string{".ipynb"}, nil
+}
+
 // notebook represents the JSON structure of a Jupyter notebook.
 type notebook struct {
 	Metadata notebookMetadata `json:"metadata"`
@@ -0,0 +1,214 @@
+// Copyright 2026 Conductor OSS
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+
+package markitdown
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Running header and footer detection. Only the first and last few lines of
+// a page are candidates, so that repeated body text such as the header row
+// of a table continued over several pages is kept.
+const (
+	runningLineBand      = 3 // lines from the top and from the bottom of a page
+	runningLineTolerance = 2 // points of vertical drift between pages
+)
+
+// removeRunningLines drops running headers and footers, such as titles,
+// page numbers and confidentiality notices: lines near the top or bottom of
+// a page whose text recurs at the same height on at least half of the pages
+// with text. Numbers are ignored when comparing text, so "Page 3 of 10"
+// matches "Page 4 of 10". The text of pages with a single line is kept.
+func removeRunningLines(pages []*pdfPage) {
+	type occurrence struct {
+		page, line int
+		top        float64
+	}
+	withText := 0
+	byKey := map[string][]occurrence{}
+	for n, p := range pages {
+		if len(p.lines) == 0 {
+			continue
+		}
+		withText++
+		if len(p.lines) == 1 {
+			continue // the line is the page's text
+		}
+		for j, l := range p.lines {
+			if j >= runningLineBand && j < len(p.lines)-runningLineBand {
+				continue
+			}
+			if key := runningLineKey(l.text()); key != "" {
+				byKey[key] = append(byKey[key], occurrence{page: n, line: j, top: l.top})
+			}
+		}
+	}
+	if withText < 2 {
+		return
+	}
+
+	drop := map[occurrence]bool{}
+	for _, occs := range byKey {
+		for _, o := range occs {
+			pagesAt := map[int]bool{}
+			for _, other := range occs {
+				if math.Abs(other.top-o.top) <= runningLineTolerance {
+					pagesAt[other.page] = true
+				}
+			}
+			if len(pagesAt) >= 2 && len(pagesAt)*2 >= withText {
+				drop[o] = true
+			}
+		}
+	}
+	if len(drop) == 0 {
+		return
+	}
+
+	for n, p := range pages {
+		var lines []pdfTextLine
+		var rects []pdfRect
+		for j, l := range p.lines {
+			if !drop[occurrence{page: n, line: j, top: l.top}] {
+				lines = append(lines, l)
+				rects = append(rects, l.rects...)
+			}
+		}
+		p.lines, p.rects = lines, rects
+	}
+}
+
+// runningLineKey normalizes the text of a line for comparison across pages:
+// lowercase, with runs of digits replaced by "#" and spaces collapsed.
+func runningLineKey(text string) string {
+	var b strings.Builder
+	inDigits := false
+	for _, r := range strings.ToLower(strings.Join(strings.Fields(text), " ")) {
+		if unicode.IsDigit(r) {
+			if !inDigits {
+				b.WriteByte('#')
+			}
+			inDigits = true
+			continue
+		}
+		inDigits = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// continueParagraph joins a paragraph split by a page break. If the text of
+// the previous page, prev, ends in a line of body text without closing
+// punctuation and next starts with a lowercase letter, the page break at the
+// end of md is replaced by a line break, or removed if a word is hyphenated
+// across the pages (see joinHyphenated, which is given words).
+func continueParagraph(md *bytes.Buffer, prev, next string, words map[string]bool) {
+	prev = strings.TrimRight(prev, "\n")
+	last := prev[strings.LastIndexByte(prev, '\n')+1:]
+	first, _, _ := strings.Cut(next, "\n")
+	if !isParagraphLine(last) || !isParagraphLine(first) || !startsLower(first) {
+		return
+	}
+	if end, _ := utf8.DecodeLastRuneInString(strings.TrimRight(last, `*_"')]”’`)); strings.ContainsRune(".!?:", end) {
+		return
+	}
+
+	md.Truncate(len(bytes.TrimRight(md.Bytes(), "\n")))
+	if hyphen := lineEndHyphen(last); hyphen >= 0 {
+		joinHyphenated(md, last, hyphen, first, words)
+	} else {
+		md.WriteString("\n")
+	}
+}
+
+// isParagraphLine reports whether a line of page Markdown is body text
+// rather than a heading or table row.
+func isParagraphLine(line string) bool {
+	return line != "" && !strings.HasPrefix(line, "#") && !strings.HasPrefix(line, "|")
+}
+
+// lineEndHyphen returns the offset of the hyphen ending the Markdown line s
+// after a letter, as a word broken at the end of a line has, or -1. The
+// hyphen may be followed by the markers closing emphasis or a link.
+func lineEndHyphen(s string) int {
+	for {
+		trimmed := strings.TrimRight(s, "*_")
+		if strings.HasSuffix(trimmed, ")") {
+			if i := strings.LastIndex(trimmed, "]("); i >= 0 {
+				trimmed = trimmed[:i]
+			}
+		}
+		if len(trimmed) == len(s) {
+			break
+		}
+		s = trimmed
+	}
+	rest, ok := strings.CutSuffix(s, "-")
+	if !ok {
+		return -1
+	}
+	if r, _ := utf8.DecodeLastRuneInString(rest); !unicode.IsLetter(r) {
+		return -1
+	}
+	return len(rest)
+}
+
+// hyphenationSuffixes start the second half of words broken by
+// hyphenation; they do not begin the words of a compound.
+var hyphenationSuffixes = []string{"tion", "sion", "tial", "cial", "ing", "ity", "ities", "ment", "ness"}
+
+// joinHyphenated joins the Markdown line ending md, whose hyphen at offset
+// hyphen breaks a word, with the following line, next. The hyphen is
+// removed when the halves are syllables of one word: the joined word occurs
+// in words, the words of the document, or the second half starts with a
+// suffix. Otherwise the hyphen is kept, as it is in a compound such as
+// "chat-optimized" or "low-level".
+func joinHyphenated(md *bytes.Buffer, line string, hyphen int, next string, words map[string]bool) {
+	before := line[:hyphen]
+	before = strings.ToLower(before[strings.LastIndexFunc(before, func(r rune) bool { return !unicode.IsLetter(r) })+1:])
+	after := strings.ToLower(strings.TrimLeft(next, "*_["))
+	if i := strings.IndexFunc(after, func(r rune) bool { return !unicode.IsLetter(r) }); i >= 0 {
+		after = after[:i]
+	}
+
+	drop := words[before+after]
+	for _, suffix := range hyphenationSuffixes {
+		drop = drop || strings.HasPrefix(after, suffix)
+	}
+	if !drop {
+		return
+	}
+	b := md.Bytes()
+	at := len(b) - len(line) + hyphen
+	copy(b[at:], b[at+len("-"):])
+	md.Truncate(len(b) - len("-"))
+}
+
+// textWords returns the lowercase words of text.
+func textWords(text string) map[string]bool {
+	words := map[string]bool{}
+	for _, w := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool { return !unicode.IsLetter(r) }) {
+		words[w] = true
+	}
+	return words
+}
+
+// startsLower reports whether s starts with a lowercase letter, after any
+// markers opening emphasis or a link.
+func startsLower(s string) bool {
+	r, _ := utf8.DecodeRuneInString(strings.TrimLeft(s, "*_["))
+	return unicode.IsLower(r)
+}
@@ -0,0 +1,116 @@
+// Copyright 2026 Conductor OSS
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+
+package markitdown
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Column detection thresholds. A gutter splits text into columns only if
+// both sides look like running text: enough lines, and lines long enough
+// that table columns and label/value pairs are not mistaken for columns.
+const (
+	minColumnLines     = 3
+	minColumnLineRunes = 15
+)
+
+// orderByColumns splits the rects of a page into blocks with a recursive
+// XY-cut and returns their lines in reading order: column by column, top to
+// bottom within each column. Regions without a column gutter are read as a
+// single column.
+func orderByColumns(rects []pdfRect, bodySize float64) []pdfTextLine {
+	minGutter := bodySize
+	if minGutter <= 0 {
+		minGutter = 10
+	}
+	var lines []pdfTextLine
+	for _, block := range xyCut(rects, minGutter) {
+		lines = append(lines, groupRectsIntoLines(block)...)
+	}
+	return lines
+}
+
+// xyCut splits rects at the widest valid column gutter, or failing that at
+// the widest horizontal gap, and recurses into the parts. It returns the
+// regions that cannot be split further, in reading order.
+func xyCut(rects []pdfRect, minGutter float64) [][]pdfRect {
+	if len(rects) < 2 {
+		return [][]pdfRect{rects}
+	}
+
+	if left, right, ok := columnCut(rects, minGutter); ok {
+		return append(xyCut(left, minGutter), xyCut(right, minGutter)...)
+	}
+
+	// Horizontal gaps: merge the vertical extents of the rects, top first.
+	sorted := append([]pdfRect(nil), rects...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].top > sorted[j].top })
+	bottom := sorted[0].bottom
+	cut, widest := 0, 0.0
+	for i := 1; i < len(sorted); i++ {
+		if gap := bottom - sorted[i].top; gap > widest {
+			cut, widest = i, gap
+		}
+		bottom = min(bottom, sorted[i].bottom)
+	}
+	if cut == 0 {
+		return [][]pdfRect{rects}
+	}
+	return append(xyCut(sorted[:cut], minGutter), xyCut(sorted[cut:], minGutter)...)
+}
+
+// columnCut splits rects at the widest vertical gap of at least minGutter
+// that separates two columns of running text.
+func columnCut(rects []pdfRect, minGutter float64) (left, right []pdfRect, ok bool) {
+	sorted := append([]pdfRect(nil), rects...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].left < sorted[j].left })
+
+	type gutter struct {
+		at    int
+		width float64
+	}
+	var gutters []gutter
+	edge := sorted[0].right
+	for i := 1; i < len(sorted); i++ {
+		if w := sorted[i].left - edge; w >= minGutter {
+			gutters = append(gutters, gutter{at: i, width: w})
+		}
+		edge = max(edge, sorted[i].right)
+	}
+	sort.SliceStable(gutters, func(i, j int) bool { return gutters[i].width > gutters[j].width })
+
+	for _, g := range gutters {
+		left, right = sorted[:g.at], sorted[g.at:]
+		if isTextColumn(left) && isTextColumn(right) {
+			return left, right, true
+		}
+	}
+	return nil, nil, false
+}
+
+// isTextColumn reports whether rects form running text: at least
+// minColumnLines lines, most of them minColumnLineRunes long or longer.
+func isTextColumn(rects []pdfRect) bool {
+	lines := groupRectsIntoLines(append([]pdfRect(nil), rects...))
+	if len(lines) < minColumnLines {
+		return false
+	}
+	long := 0
+	for _, l := range lines {
+		if utf8.RuneCountInString(strings.TrimSpace(l.text())) >= minColumnLineRunes {
+			long++
+		}
+	}
+	return long*2 >= len(lines)
+}
@@ -0,0 +1,180 @@
+// Copyright 2026 Conductor OSS
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+
+package markitdown
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/klippa-app/go-pdfium"
+	"github.com/klippa-app/go-pdfium/enums"
+	"github.com/klippa-app/go-pdfium/references"
+	"github.com/klippa-app/go-pdfium/requests"
+	"github.com/klippa-app/go-pdfium/responses"
+)
+
+// pdfLink is a URI link annotation on a page, with the text it covers.
+type pdfLink struct {
+	left, top, right, bottom float64
+	uri                      string
+	text                     string
+}
+
+// pageLinks returns the URI link annotations of a page. Links to other
+// places in the document are skipped.
+func (c *PdfConverter) pageLinks(instance pdfium.Pdfium, doc *responses.OpenDocument, pageIdx int) []pdfLink {
+	page := requests.Page{
+		ByIndex: &requests.PageByIndex{
+			Document: doc.Document,
+			Index:    pageIdx,
+		},
+	}
+
+	var links []pdfLink
+	for pos := 0; ; {
+		resp, err := instance.FPDFLink_Enumerate(&requests.FPDFLink_Enumerate{Page: page, StartPos: pos})
+		if err != nil || resp.Link == nil {
+			break
+		}
+		if l, ok := c.uriLink(instance, doc, *resp.Link); ok {
+			links = append(links, l)
+		}
+		if resp.NextStartPos == nil || *resp.NextStartPos <= pos {
+			break
+		}
+		pos = *resp.NextStartPos
+	}
+	if len(links) == 0 {
+		return nil
+	}
+
+	textPage, err := instance.FPDFText_LoadPage(&requests.FPDFText_LoadPage{Page: page})
+	if err != nil {
+		return links
+	}
+	defer func() {
+		_, _ = instance.FPDFText_ClosePage(&requests.FPDFText_ClosePage{TextPage: textPage.TextPage})
+	}()
+	for i, l := range links {
+		text, err := instance.FPDFText_GetBoundedText(&requests.FPDFText_GetBoundedText{
+			TextPage: textPage.TextPage,
+			Left:     l.left,
+			Top:      l.top,
+			Right:    l.right,
+			Bottom:   l.bottom,
+		})
+		if err == nil {
+			links[i].text = strings.TrimSpace(text.Text)
+		}
+	}
+	return links
+}
+
+// uriLink returns the area and target of a link annotation with a URI
+// action.
+func (c *PdfConverter) uriLink(instance pdfium.Pdfium, doc *responses.OpenDocument, link references.FPDF_LINK) (pdfLink, bool) {
+	action, err := instance.FPDFLink_GetAction(&requests.FPDFLink_GetAction{Link: link})
+	if err != nil || action.Action == nil {
+		return pdfLink{}, false
+	}
+	info, err := instance.GetActionInfo(&requests.GetActionInfo{Document: doc.Document, Action: *action.Action})
+	if err != nil || info.ActionInfo.Type != enums.FPDF_ACTION_ACTION_URI || info.ActionInfo.URIPath == nil {
+		return pdfLink{}, false
+	}
+	uri := strings.TrimSpace(*info.ActionInfo.URIPath)
+	rect, err := instance.FPDFLink_GetAnnotRect(&requests.FPDFLink_GetAnnotRect{Link: link})
+	if uri == "" || err != nil || rect.Rect == nil {
+		return pdfLink{}, false
+	}
+	r := rect.Rect
+	return pdfLink{
+		left:   float64(min(r.Left, r.Right)),
+		right:  float64(max(r.Left, r.Right)),
+		top:    float64(max(r.Top, r.Bottom)),
+		bottom: float64(min(r.Top, r.Bottom)),
+		uri:    uri,
+	}, true
+}
+
+// applyLinks marks the rects covered by each link. When a rect contains more
+// than the link text, it is split so that only the linked text is marked.
+func applyLinks(rects []pdfRect, links []pdfLink) []pdfRect {
+	for _, l := range links {
+		var hits []int
+		for i, r := range rects {
+			midY := (r.top + r.bottom) / 2
+			if r.link == "" && midY >= l.bottom && midY <= l.top && r.right > l.left && r.left < l.right {
+				hits = append(hits, i)
+			}
+		}
+		if len(hits) == 0 {
+			continue
+		}
+
+		split := false
+		if l.text != "" {
+			for _, i := range hits {
+				if at := strings.Index(rects[i].text, l.text); at >= 0 {
+					parts, linked := splitRect(rects[i], at, at+len(l.text))
+					parts[linked].link = l.uri
+					rects = append(rects[:i], append(parts, rects[i+1:]...)...)
+					split = true
+					break
+				}
+			}
+		}
+		if !split {
+			for _, i := range hits {
+				if mid := (rects[i].left + rects[i].right) / 2; mid >= l.left && mid <= l.right {
+					rects[i].link = l.uri
+				}
+			}
+		}
+	}
+	return rects
+}
+
+// splitRect splits r into the text before byte offset from, the text up to
+// to, and the rest, omitting empty parts, and returns the parts with the
+// index of the middle one. Positions are estimated assuming characters of
+// equal width.
+func splitRect(r pdfRect, from, to int) ([]pdfRect, int) {
+	total := utf8.RuneCountInString(r.text)
+	x := func(off int) float64 {
+		return r.left + (r.right-r.left)*float64(utf8.RuneCountInString(r.text[:off]))/float64(total)
+	}
+
+	var parts []pdfRect
+	if from > 0 {
+		before := r
+		before.text, before.right = r.text[:from], x(from)
+		parts = append(parts, before)
+	}
+	mid := r
+	mid.text, mid.left, mid.right = r.text[from:to], x(from), x(to)
+	parts = append(parts, mid)
+	if to < len(r.text) {
+		after := r
+		after.text, after.left = r.text[to:], x(to)
+		parts = append(parts, after)
+	}
+	if from > 0 {
+		return parts, 1
+	}
+	return parts, 0
+}
+
+// markdownLinkTarget escapes the characters of a URI that would end a
+// Markdown link target.
+func markdownLinkTarget(uri string) string {
+	return strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29", "<", "%3C", ">", "%3E").Replace(uri)
+}
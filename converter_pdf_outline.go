@@ -0,0 +1,202 @@
+// Copyright 2026 Conductor OSS
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+
+package markitdown
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/klippa-app/go-pdfium"
+	"github.com/klippa-app/go-pdfium/enums"
+	"github.com/klippa-app/go-pdfium/references"
+	"github.com/klippa-app/go-pdfium/requests"
+	"github.com/klippa-app/go-pdfium/responses"
+)
+
+// maxHeadingLevel is the deepest Markdown heading level.
+const maxHeadingLevel = 6
+
+// Bounds on reading the outline, which a malformed or malicious PDF can make
+// endless through cycles or, through shared items, exponentially large.
+const (
+	maxOutlineDepth   = 64
+	maxOutlineEntries = 10000
+)
+
+// pdfOutlineEntry is a bookmark from a PDF's document outline, flattened.
+type pdfOutlineEntry struct {
+	title string
+	level int // 1 for top-level bookmarks
+	page  int // 0-based page index, or -1 if the bookmark has no destination
+}
+
+// outline returns the document outline in depth-first order.
+func (c *PdfConverter) outline(ctx context.Context, instance pdfium.Pdfium, doc *responses.OpenDocument) ([]pdfOutlineEntry, error) {
+	// GetBookmarks recurses without end on outlines whose items link back to
+	// themselves, so the outline is first checked item by item.
+	if err := checkOutline(ctx, instance, doc); err != nil {
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		stateFrom(ctx).warn(fmt.Sprintf("PDF outline ignored: %v", err))
+		return nil, nil
+	}
+	resp, err := instance.GetBookmarks(&requests.GetBookmarks{Document: doc.Document})
+	if err != nil {
+		return nil, nil
+	}
+	var entries []pdfOutlineEntry
+	var walk func(bookmarks []responses.GetBookmarksBookmark, level int)
+	walk = func(bookmarks []responses.GetBookmarksBookmark, level int) {
+		for _, b := range bookmarks {
+			e := pdfOutlineEntry{title: strings.Join(strings.Fields(b.Title), " "), level: level, page: -1}
+			switch {
+			case b.DestInfo != nil:
+				e.page = b.DestInfo.PageIndex
+			case b.ActionInfo != nil && b.ActionInfo.Type == enums.FPDF_ACTION_ACTION_GOTO && b.ActionInfo.DestInfo != nil:
+				e.page = b.ActionInfo.DestInfo.PageIndex
+			}
+			if e.title != "" {
+				entries = append(entries, e)
+			}
+			walk(b.Children, level+1)
+		}
+	}
+	walk(resp.Bookmarks, 1)
+	return entries, nil
+}
+
+// checkOutline walks the outline item by item and fails if it is deeper or
+// larger than maxOutlineDepth and maxOutlineEntries. An outline whose items
+// link back to themselves never ends, so it fails the check too. Items
+// cannot be recognized when the walk returns to them: pdfium hands out a new
+// reference on every lookup, and identical titles are common.
+func checkOutline(ctx context.Context, instance pdfium.Pdfium, doc *responses.OpenDocument) error {
+	visited := 0
+	var walk func(bookmark *references.FPDF_BOOKMARK, depth int) error
+	walk = func(bookmark *references.FPDF_BOOKMARK, depth int) error {
+		if bookmark != nil && depth > maxOutlineDepth {
+			return fmt.Errorf("nested more than %d levels deep", maxOutlineDepth)
+		}
+		for bookmark != nil {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if visited++; visited > maxOutlineEntries {
+				return fmt.Errorf("more than %d entries", maxOutlineEntries)
+			}
+			child, err := instance.FPDFBookmark_GetFirstChild(&requests.FPDFBookmark_GetFirstChild{Document: doc.Document, Bookmark: bookmark})
+			if err != nil {
+				return err
+			}
+			if err := walk(child.Bookmark, depth+1); err != nil {
+				return err
+			}
+			next, err := instance.FPDFBookmark_GetNextSibling(&requests.FPDFBookmark_GetNextSibling{Document: doc.Document, Bookmark: *bookmark})
+			if err != nil {
+				return err
+			}
+			bookmark = next.Bookmark
+		}
+		return nil
+	}
+
+	first, err := instance.FPDFBookmark_GetFirstChild(&requests.FPDFBookmark_GetFirstChild{Document: doc.Document})
+	if err != nil {
+		return err
+	}
+	return walk(first.Bookmark, 1)
+}
+
+// pdfHeadings assigns heading levels to the lines of a page from the
+// outline entries pointing at it. Each entry marks at most one line.
+type pdfHeadings struct {
+	entries []pdfOutlineEntry
+	used    []bool
+}
+
+// outlineHeadings returns the headings of page pageIdx.
+func outlineHeadings(outline []pdfOutlineEntry, pageIdx int) *pdfHeadings {
+	h := &pdfHeadings{}
+	for _, e := range outline {
+		if e.page == pageIdx {
+			h.entries = append(h.entries, e)
+		}
+	}
+	h.used = make([]bool, len(h.entries))
+	return h
+}
+
+// level returns the heading level of a line with the given text, or 0 if
+// no outline entry names it. Section numbers that appear in only one of the
+// line and the entry are ignored.
+func (h *pdfHeadings) level(text string) int {
+	line := headingKey(text)
+	if isDigits(line) { // page numbers and the like
+		return 0
+	}
+	for i, e := range h.entries {
+		if h.used[i] {
+			continue
+		}
+		title := headingKey(e.title)
+		if title == "" {
+			continue
+		}
+		if line == title ||
+			(strings.HasSuffix(line, title) && isDigits(strings.TrimSuffix(line, title))) ||
+			(strings.HasSuffix(title, line) && isDigits(strings.TrimSuffix(title, line))) {
+			h.used[i] = true
+			return min(e.level, maxHeadingLevel)
+		}
+	}
+	return 0
+}
+
+// headingKey reduces heading text to its lowercase letters and digits.
+func headingKey(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(stripMarkdownFormatting(s)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// renderOutlineTOC renders the outline as a nested list, with the page
+// number of each entry that has one.
+func renderOutlineTOC(outline []pdfOutlineEntry) string {
+	var b strings.Builder
+	b.WriteString("# Contents\n\n")
+	for _, e := range outline {
+		b.WriteString(strings.Repeat("  ", e.level-1))
+		b.WriteString("- ")
+		b.WriteString(e.title)
+		if e.page >= 0 {
+			fmt.Fprintf(&b, " (page %d)", e.page+1)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -12,42 +12,139 @@
 package markitdown
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"math"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/klippa-app/go-pdfium"
+	pdfiumerrors "github.com/klippa-app/go-pdfium/errors"
 	"github.com/klippa-app/go-pdfium/requests"
 	"github.com/klippa-app/go-pdfium/responses"
 	"github.com/klippa-app/go-pdfium/webassembly"
 )
 
-var (
-	pdfiumPool     pdfium.Pool
-	pdfiumPoolOnce sync.Once
-	pdfiumPoolErr  error
+// Defaults for the PDF engine.
+const (
+	DefaultPDFWorkers        = 1
+	DefaultPDFAcquireTimeout = 30 * time.Second
 )
 
-func initPdfiumPool() {
-	pdfiumPool, pdfiumPoolErr = webassembly.Init(webassembly.Config{
-		MinIdle:  1,
-		MaxIdle:  1,
-		MaxTotal: 1,
-	})
+// ErrClosed is returned when converting a PDF with a MarkItDown after
+// Close.
+var ErrClosed = errors.New("markitdown is closed")
+
+// pdfiumPool is a pool of pdfium WebAssembly instances, started on first
+// use.
+type pdfiumPool struct {
+	size int
+
+	mu     sync.Mutex
+	pool   pdfium.Pool
+	active int // acquisitions not yet released
+	closed bool
+}
+
+// defaultPdfiumPool serves PdfConverters created without a MarkItDown and
+// MarkItDowns without WithPDFWorkers. It is never closed.
+var defaultPdfiumPool = &pdfiumPool{size: DefaultPDFWorkers}
+
+// acquire waits up to timeout (if positive) for an instance, starting the
+// pool if needed. The returned function releases the instance; a pool closed
+// in the meantime shuts down once its last instance is released.
+func (p *pdfiumPool) acquire(ctx context.Context, timeout time.Duration) (pdfium.Pdfium, func(), error) {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil, nil, ErrClosed
+	}
+	if p.pool == nil {
+		pool, err := webassembly.Init(webassembly.Config{
+			MinIdle:  1,
+			MaxIdle:  p.size,
+			MaxTotal: p.size,
+		})
+		if err != nil {
+			p.mu.Unlock()
+			return nil, nil, fmt.Errorf("init pdfium: %w", err)
+		}
+		p.pool = pool
+	}
+	pool := p.pool
+	p.active++
+	p.mu.Unlock()
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	instance, err := pool.GetInstanceWithContext(ctx)
+	if err != nil {
+		p.release()
+		return nil, nil, fmt.Errorf("get pdfium instance: %w", err)
+	}
+	return instance, func() {
+		instance.Close()
+		p.release()
+	}, nil
+}
+
+// release ends an acquisition, shutting the pool down if it was the last
+// one of a closed pool.
+func (p *pdfiumPool) release() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.active--
+	if p.closed && p.active == 0 && p.pool != nil {
+		p.pool.Close()
+		p.pool = nil
+	}
+}
+
+// close shuts down the pool's instances, if it was started, or marks it to
+// shut down when the instances in use are released. The pool cannot be used
+// afterwards.
+func (p *pdfiumPool) close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return nil
+	}
+	p.closed = true
+	if p.pool == nil || p.active > 0 {
+		return nil
+	}
+	pool := p.pool
+	p.pool = nil
+	return pool.Close()
 }
 
 // PdfConverter handles PDF files using the PDFium library via WebAssembly.
-type PdfConverter struct{}
+// Pages without text are rendered and passed to the OCR engine set by
+// WithOCR, if any.
+type PdfConverter struct {
+	markitdown *MarkItDown
+}
 
 // NewPdfConverter creates a new PdfConverter.
 func NewPdfConverter() *PdfConverter {
 	return &PdfConverter{}
 }
 
+// NewPdfConverterFor creates a PdfConverter that applies m's PDF and OCR
+// options and uses its PDF engine instances.
+func NewPdfConverterFor(m *MarkItDown) *PdfConverter {
+	return &PdfConverter{markitdown: m}
+}
+
 func (c *PdfConverter) Accepts(info StreamInfo) bool {
 	if info.Extension == ".pdf" {
 		return true
@@ -56,26 +153,46 @@ func (c *PdfConverter) Accepts(info StreamInfo) bool {
 	return strings.HasPrefix(mime, "application/pdf")
 }
 
+func (c *PdfConverter) Formats() (extensions, mimeTypes []string) {
+	return []string{".pdf"}, []string{"application/pdf"}
+}
+
 func (c *PdfConverter) Convert(reader io.ReadSeeker, info StreamInfo) (*DocumentConverterResult, error) {
-	pdfiumPoolOnce.Do(initPdfiumPool)
-	if pdfiumPoolErr != nil {
-		return nil, fmt.Errorf("init pdfium: %w", pdfiumPoolErr)
-	}
+	return c.ConvertContext(context.Background(), reader, info)
+}
 
-	instance, err := pdfiumPool.GetInstance(30 * time.Second)
+// ConvertContext converts a PDF, checking ctx while waiting for a pdfium
+// instance and between pages.
+func (c *PdfConverter) ConvertContext(ctx context.Context, reader io.ReadSeeker, info StreamInfo) (*DocumentConverterResult, error) {
+	pool, timeout := defaultPdfiumPool, DefaultPDFAcquireTimeout
+	if m := c.markitdown; m != nil {
+		if m.closed.Load() {
+			return nil, ErrClosed
+		}
+		pool, timeout = m.pdfium, m.pdfAcquireTimeout
+	}
+	instance, release, err := pool.acquire(ctx, timeout)
 	if err != nil {
-		return nil, fmt.Errorf("get pdfium instance: %w", err)
+		return nil, err
 	}
-	defer instance.Close()
+	defer release()
 
 	data, err := io.ReadAll(reader)
 	if err != nil {
 		return nil, fmt.Errorf("read PDF: %w", err)
 	}
 
-	doc, err := instance.OpenDocument(&requests.OpenDocument{
+	req := &requests.OpenDocument{
 		File: &data,
-	})
+	}
+	password := c.markitdown.documentPassword(ctx, info)
+	if password != "" {
+		req.Password = &password
+	}
+	doc, err := instance.OpenDocument(req)
+	if errors.Is(err, pdfiumerrors.ErrPassword) {
+		return nil, &PasswordRequiredError{Incorrect: password != ""}
+	}
 	if err != nil {
 		return nil, fmt.Errorf("open PDF: %w", err)
 	}
@@ -91,28 +208,172 @@ func (c *PdfConverter) Convert(reader io.ReadSeeker, info StreamInfo) (*Document
 	if err != nil {
 		return nil, fmt.Errorf("get page count: %w", err)
 	}
+	pages, err := c.selectPages(pageCountResp.PageCount)
+	if err != nil {
+		return nil, err
+	}
+	if err := stateFrom(ctx).checkPages(len(pages)); err != nil {
+		return nil, err
+	}
+	markers := c.markitdown != nil && c.markitdown.pdfPageMarkers
+
+	var md bytes.Buffer
+	result := &DocumentConverterResult{}
+	c.setInfoMetadata(instance, doc, result)
+	result.setMetadata(MetadataPageCount, strconv.Itoa(pageCountResp.PageCount))
 
-	var md strings.Builder
+	// The outline, if any, determines the headings and may be listed first.
+	outline, err := c.outline(ctx, instance, doc)
+	if err != nil {
+		return nil, err
+	}
+	if len(outline) > 0 && c.markitdown != nil && c.markitdown.pdfTOC {
+		md.WriteString(renderOutlineTOC(outline))
+		md.WriteString("\n")
+	}
+
+	// Read all pages first: running headers and footers are found by
+	// comparing them.
+	read := make([]*pdfPage, len(pages))
+	for n, i := range pages {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		read[n] = c.readPage(instance, doc, i)
+	}
+	if c.markitdown == nil || !c.markitdown.headersFooters {
+		removeRunningLines(read)
+	}
+	// A word hyphenated at a line break is joined if it occurs elsewhere.
+	words := documentWords(read)
 
-	for i := 0; i < pageCountResp.PageCount; i++ {
-		text := c.extractStructuredPage(instance, doc, i)
+	prev := ""
+	for _, p := range read {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		var headings *pdfHeadings
+		if len(outline) > 0 {
+			headings = outlineHeadings(outline, p.index)
+		}
+		text := c.renderPage(instance, doc, p, headings, words)
+		if text == "" && !p.hasText {
+			if text, err = c.ocrPage(ctx, instance, doc, p.index); err != nil {
+				return nil, err
+			}
+		}
 		if text == "" {
 			continue
 		}
+		if markers {
+			fmt.Fprintf(&md, "<!-- Page %d -->\n\n", p.index+1)
+		} else {
+			// A paragraph split by the page break continues on this page
+			continueParagraph(&md, prev, text, words)
+		}
 		md.WriteString(text)
 		md.WriteString("\n\n")
+		prev = text
+		result.Segments = append(result.Segments, Segment{
+			Kind:     SegmentPage,
+			Number:   p.index + 1,
+			Markdown: text,
+		})
 	}
 
-	result := md.String()
-	if strings.TrimSpace(result) == "" {
-		return &DocumentConverterResult{
-			Markdown: "[No readable text content found in PDF]",
-		}, nil
+	result.Markdown = md.String()
+	if strings.TrimSpace(result.Markdown) == "" {
+		result.Markdown = "[No readable text content found in PDF]"
 	}
 
-	return &DocumentConverterResult{
-		Markdown: result,
-	}, nil
+	return result, nil
+}
+
+// selectPages returns the 0-based indexes of the pages to convert, as
+// selected by WithPDFPages.
+func (c *PdfConverter) selectPages(count int) ([]int, error) {
+	spec := ""
+	if c.markitdown != nil {
+		spec = strings.TrimSpace(c.markitdown.pdfPages)
+	}
+	if spec == "" {
+		pages := make([]int, count)
+		for i := range pages {
+			pages[i] = i
+		}
+		return pages, nil
+	}
+	return parsePageRanges(spec, count)
+}
+
+// parsePageRanges parses a comma-separated list of 1-based page numbers and
+// ranges ("1-5,10", "3-") into sorted, distinct 0-based page indexes below
+// count.
+func parsePageRanges(spec string, count int) ([]int, error) {
+	selected := make(map[int]bool)
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		from, to, isRange := strings.Cut(part, "-")
+		first, err := strconv.Atoi(strings.TrimSpace(from))
+		last := first
+		if err == nil && isRange {
+			if to = strings.TrimSpace(to); to == "" {
+				last = count
+			} else {
+				last, err = strconv.Atoi(to)
+			}
+		}
+		if err != nil || first < 1 || last < first {
+			return nil, fmt.Errorf("invalid page range %q", part)
+		}
+		for p := first; p <= min(last, count); p++ {
+			selected[p-1] = true
+		}
+	}
+	pages := make([]int, 0, len(selected))
+	for p := range selected {
+		pages = append(pages, p)
+	}
+	sort.Ints(pages)
+	return pages, nil
+}
+
+// setInfoMetadata copies the document information dictionary into the result.
+func (c *PdfConverter) setInfoMetadata(instance pdfium.Pdfium, doc *responses.OpenDocument, result *DocumentConverterResult) {
+	meta := func(tag string) string {
+		resp, err := instance.FPDF_GetMetaText(&requests.FPDF_GetMetaText{
+			Document: doc.Document,
+			Tag:      tag,
+		})
+		if err != nil {
+			return ""
+		}
+		return resp.Value
+	}
+	result.Title = strings.TrimSpace(meta("Title"))
+	result.setMetadata(MetadataTitle, meta("Title"))
+	result.setMetadata(MetadataAuthor, meta("Author"))
+	result.setMetadata(MetadataSubject, meta("Subject"))
+	result.setMetadata(MetadataKeywords, meta("Keywords"))
+	result.setMetadata(MetadataCreated, parsePDFDate(meta("CreationDate")))
+	result.setMetadata(MetadataModified, parsePDFDate(meta("ModDate")))
+}
+
+// parsePDFDate converts a PDF date string (D:YYYYMMDDHHmmSSOHH'mm') to
+// RFC 3339. Values that cannot be parsed are returned unchanged.
+func parsePDFDate(s string) string {
+	v := strings.TrimPrefix(strings.TrimSpace(s), "D:")
+	if v == "" {
+		return ""
+	}
+	v = strings.ReplaceAll(v, "'", "")
+	layouts := []string{"20060102150405Z0700", "20060102150405Z07", "20060102150405", "200601021504", "20060102", "200601", "2006"}
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, v); err == nil {
+			return t.Format(time.RFC3339)
+		}
+	}
+	return s
 }
 
 // pdfRect represents a text rectangle with font metadata from PDFium.
@@ -124,6 +385,7 @@ type pdfRect struct {
 	bottom   float64
 	fontSize float64
 	fontName string
+	link     string // URI of the link annotation covering the text
 }
 
 // pdfTextLine represents a line of text built from grouped rects.
@@ -144,8 +406,34 @@ func (l *pdfTextLine) text() string {
 	return b.String()
 }
 
-// extractStructuredPage extracts text from a page with markdown formatting.
-func (c *PdfConverter) extractStructuredPage(instance pdfium.Pdfium, doc *responses.OpenDocument, pageIdx int) string {
+// pdfPage is the text of a page before rendering.
+type pdfPage struct {
+	index int
+	rects []pdfRect
+	lines []pdfTextLine
+	plain string // plain text, when the page has no structured text
+
+	hasText bool // the page has text, even if it was all removed
+}
+
+// documentWords returns the lowercase words of the text of pages.
+func documentWords(pages []*pdfPage) map[string]bool {
+	var text strings.Builder
+	for _, p := range pages {
+		text.WriteString(p.plain)
+		text.WriteString("\n")
+		for i := range p.lines {
+			text.WriteString(p.lines[i].text())
+			text.WriteString("\n")
+		}
+	}
+	return textWords(text.String())
+}
+
+// readPage reads the text rects of a page, marking those covered by links,
+// and groups them into lines.
+func (c *PdfConverter) readPage(instance pdfium.Pdfium, doc *responses.OpenDocument, pageIdx int) *pdfPage {
+	p := &pdfPage{index: pageIdx}
 	structured, err := instance.GetPageTextStructured(&requests.GetPageTextStructured{
 		Page: requests.Page{
 			ByIndex: &requests.PageByIndex{
@@ -158,13 +446,14 @@ func (c *PdfConverter) extractStructuredPage(instance pdfium.Pdfium, doc *respon
 	})
 	if err != nil || len(structured.Rects) == 0 {
 		// Fallback to plain text
-		return c.extractPlainPage(instance, doc, pageIdx)
+		p.plain = c.extractPlainPage(instance, doc, pageIdx)
+		return p
 	}
 
 	// Convert rects to our type
-	var rects []pdfRect
 	for _, r := range structured.Rects {
-		text := r.Text
+		// PDFium reports a hyphen ending a line as \x02
+		text := strings.ReplaceAll(r.Text, "\x02", "-")
 		if strings.TrimSpace(text) == "" {
 			continue
 		}
@@ -179,21 +468,80 @@ func (c *PdfConverter) extractStructuredPage(instance pdfium.Pdfium, doc *respon
 			pr.fontSize = r.FontInformation.Size
 			pr.fontName = r.FontInformation.Name
 		}
-		rects = append(rects, pr)
+		p.rects = append(p.rects, pr)
 	}
 
-	if len(rects) == 0 {
-		return ""
+	if len(p.rects) == 0 {
+		return p
 	}
+	p.hasText = true
+
+	// Mark text covered by link annotations
+	p.rects = applyLinks(p.rects, c.pageLinks(instance, doc, pageIdx))
 
 	// Group rects into lines by Y position
-	lines := groupRectsIntoLines(rects)
+	p.lines = groupRectsIntoLines(p.rects)
+	return p
+}
+
+// renderPage renders a page read by readPage with markdown formatting.
+// Headings come from headings when the document has an outline, and are
+// guessed from font sizes otherwise.
+func (c *PdfConverter) renderPage(instance pdfium.Pdfium, doc *responses.OpenDocument, p *pdfPage, headings *pdfHeadings, words map[string]bool) string {
+	if len(p.lines) == 0 {
+		return p.plain
+	}
+	lines := p.lines
 
 	// Determine the body font size (most common size)
 	bodySize := detectBodyFontSize(lines)
 
+	// Read multi-column layouts column by column
+	if c.markitdown != nil && c.markitdown.pdfLayout == PDFLayoutColumns {
+		lines = orderByColumns(p.rects, bodySize)
+	}
+
+	// Detect tables from column alignment and ruling lines
+	tables := detectTables(lines, c.pageRulings(instance, doc, p.index), bodySize)
+
 	// Render lines as markdown
-	return renderMarkdownFromLines(lines, bodySize)
+	return renderMarkdownFromLines(lines, bodySize, tables, headings, words)
+}
+
+// ocrPage renders a page without text and returns the text recognized in it
+// by the OCR engine, or "" without one. OCR failures are recorded as
+// conversion warnings.
+func (c *PdfConverter) ocrPage(ctx context.Context, instance pdfium.Pdfium, doc *responses.OpenDocument, pageIdx int) (string, error) {
+	if c.markitdown == nil || c.markitdown.ocr == nil {
+		return "", nil
+	}
+	st := stateFrom(ctx)
+	rendered, err := instance.RenderToFile(&requests.RenderToFile{
+		RenderPageInDPI: &requests.RenderPageInDPI{
+			Page: requests.Page{
+				ByIndex: &requests.PageByIndex{
+					Document: doc.Document,
+					Index:    pageIdx,
+				},
+			},
+			DPI: ocrDPI,
+		},
+		OutputFormat: requests.RenderToFileOutputFormatPNG,
+		OutputTarget: requests.RenderToFileOutputTargetBytes,
+	})
+	if err != nil || rendered.ImageBytes == nil {
+		st.warn(fmt.Sprintf("render page %d for OCR: %v", pageIdx+1, err))
+		return "", nil
+	}
+	text, err := c.markitdown.ocr.Recognize(ctx, *rendered.ImageBytes, "image/png")
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return "", ctxErr
+		}
+		st.warn(fmt.Sprintf("OCR page %d: %v", pageIdx+1, err))
+		return "", nil
+	}
+	return strings.TrimSpace(text), nil
 }
 
 // extractPlainPage is the fallback plain text extractor.
@@ -370,12 +718,29 @@ func headingLevel(fontSize, bodySize float64, isBold bool) int {
 	}
 }
 
-// renderMarkdownFromLines converts structured PDF lines into markdown text.
-func renderMarkdownFromLines(lines []pdfTextLine, bodySize float64) string {
-	var md strings.Builder
+// renderMarkdownFromLines converts structured PDF lines into markdown text,
+// rendering the lines of each detected table as a markdown table. Heading
+// levels come from headings if it is not nil, and from font sizes otherwise.
+// words are the words of the document, for joining hyphenated words.
+func renderMarkdownFromLines(lines []pdfTextLine, bodySize float64, tables []pdfTable, headings *pdfHeadings, words map[string]bool) string {
+	var md bytes.Buffer
 	prevWasHeading := false
+	prevLine, prevHyphen := "", -1
 
-	for i, line := range lines {
+	for i := 0; i < len(lines); i++ {
+		if len(tables) > 0 && tables[0].start == i {
+			if md.Len() > 0 {
+				md.WriteString("\n")
+			}
+			md.WriteString(renderMarkdownTable(tables[0].rows))
+			md.WriteString("\n")
+			i = tables[0].end - 1
+			tables = tables[1:]
+			prevWasHeading = true // no paragraph break needed after the table
+			prevHyphen = -1
+			continue
+		}
+		line := lines[i]
 		rawText := strings.TrimSpace(line.text())
 		if rawText == "" {
 			continue
@@ -390,18 +755,23 @@ func renderMarkdownFromLines(lines []pdfTextLine, bodySize float64) string {
 			}
 		}
 
-		isBold := fontIsBold(line.fontName)
+		var level int
+		if headings != nil {
+			// Headings are the lines named by the document outline
+			level = headings.level(rawText)
+		} else {
+			isBold := fontIsBold(line.fontName)
 
-		// Determine heading level from font size
-		level := headingLevel(line.fontSize, bodySize, isBold)
+			// Determine heading level from font size
+			level = headingLevel(line.fontSize, bodySize, isBold)
 
-		// Additional heuristic: standalone short bold lines at body size
-		// are likely subheadings (e.g. "References", "Acknowledgements")
-		if level == 0 && isBold && line.fontSize >= bodySize && allRectsAreBold(line.rects) {
-			text := strings.TrimSpace(line.text())
-			// Only treat as heading if reasonably short (not a bold paragraph)
-			if len(text) < 80 {
-				level = 4
+			// Additional heuristic: standalone short bold lines at body size
+			// are likely subheadings (e.g. "References", "Acknowledgements")
+			if level == 0 && isBold && line.fontSize >= bodySize && allRectsAreBold(line.rects) {
+				// Only treat as heading if reasonably short (not a bold paragraph)
+				if len(rawText) < 80 {
+					level = 4
+				}
 			}
 		}
 
@@ -423,9 +793,11 @@ func renderMarkdownFromLines(lines []pdfTextLine, bodySize float64) string {
 			md.WriteString(stripMarkdownFormatting(lineMarkdown))
 			md.WriteString("\n\n")
 			prevWasHeading = true
+			prevHyphen = -1
 		} else {
 			// Check if there's a significant vertical gap from previous line
 			// (indicating a paragraph break)
+			paragraphBreak := false
 			if i > 0 && !prevWasHeading {
 				prevLine := lines[i-1]
 				gap := prevLine.bottom - line.top
@@ -436,12 +808,20 @@ func renderMarkdownFromLines(lines []pdfTextLine, bodySize float64) string {
 				// Gap larger than ~1.5x line height suggests a paragraph break
 				if gap > lineHeight*1.5 {
 					md.WriteString("\n")
+					paragraphBreak = true
 				}
 			}
 
+			// Rejoin a word hyphenated across the line break
+			if prevHyphen >= 0 && !paragraphBreak && startsLower(lineMarkdown) {
+				md.Truncate(md.Len() - len("\n"))
+				joinHyphenated(&md, prevLine, prevHyphen, lineMarkdown, words)
+			}
+
 			md.WriteString(lineMarkdown)
 			md.WriteString("\n")
 			prevWasHeading = false
+			prevLine, prevHyphen = lineMarkdown, lineEndHyphen(lineMarkdown)
 		}
 	}
 
@@ -449,7 +829,7 @@ func renderMarkdownFromLines(lines []pdfTextLine, bodySize float64) string {
 }
 
 // buildLineMarkdown renders a line's rects with inline markdown formatting
-// (bold, italic, code) based on font properties.
+// (bold, italic, code) based on font properties, and links.
 func buildLineMarkdown(rects []pdfRect, bodySize float64) string {
 	// Merge consecutive rects with the same formatting to avoid split markers
 	type fmtRun struct {
@@ -457,6 +837,7 @@ func buildLineMarkdown(rects []pdfRect, bodySize float64) string {
 		bold   bool
 		italic bool
 		mono   bool
+		link   string
 	}
 
 	var runs []fmtRun
@@ -476,12 +857,13 @@ func buildLineMarkdown(rects []pdfRect, bodySize float64) string {
 			bold:   fontIsBold(r.fontName),
 			italic: fontIsItalic(r.fontName),
 			mono:   fontIsMono(r.fontName),
+			link:   r.link,
 		}
 
 		// Merge with previous run if same formatting
 		if len(runs) > 0 {
 			prev := &runs[len(runs)-1]
-			if prev.bold == run.bold && prev.italic == run.italic && prev.mono == run.mono {
+			if prev.bold == run.bold && prev.italic == run.italic && prev.mono == run.mono && prev.link == run.link {
 				prev.text += text
 				continue
 			}
@@ -490,8 +872,9 @@ func buildLineMarkdown(rects []pdfRect, bodySize float64) string {
 	}
 
 	// Render merged runs
-	var b strings.Builder
+	var b bytes.Buffer
 	for _, run := range runs {
+		start := b.Len()
 		text := run.text
 		if run.mono {
 			b.WriteString("`")
@@ -528,6 +911,15 @@ func buildLineMarkdown(rects []pdfRect, bodySize float64) string {
 		} else {
 			b.WriteString(text)
 		}
+		if run.link != "" {
+			// Wrap the rendered run in a link, keeping surrounding spaces outside
+			rendered := b.String()[start:]
+			b.Truncate(start)
+			label := strings.TrimSpace(rendered)
+			lead := rendered[:strings.Index(rendered, label)]
+			b.WriteString(lead + "[" + label + "](" + markdownLinkTarget(run.link) + ")")
+			b.WriteString(rendered[len(lead)+len(label):])
+		}
 	}
 	return b.String()
 }
@@ -0,0 +1,428 @@
+// Copyright 2026 Conductor OSS
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+
+package markitdown
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/klippa-app/go-pdfium"
+	"github.com/klippa-app/go-pdfium/enums"
+	"github.com/klippa-app/go-pdfium/requests"
+	"github.com/klippa-app/go-pdfium/responses"
+)
+
+// Table detection thresholds, in PDF points unless noted.
+const (
+	rulingMaxThickness = 3   // thicker paths are boxes, whose edges count as rulings
+	rulingMinLength    = 8   // shorter paths are glyph decorations, bullets etc.
+	rulingTolerance    = 2   // rulings closer than this are the same line
+	minAlignedRows     = 3   // rows needed to treat aligned text as a table
+	maxTableRowGap     = 2.0 // max gap between table rows, in line heights
+	maxCellRunes       = 40  // tables whose cells are mostly longer are prose columns
+)
+
+// pdfRuling is a horizontal or vertical line drawn on a page, such as a
+// table border. pos is its y (horizontal) or x (vertical) coordinate and
+// from..to its extent along the other axis.
+type pdfRuling struct {
+	horizontal bool
+	pos        float64
+	from, to   float64
+}
+
+// pdfTable is a table detected in lines[start:end] of a page.
+type pdfTable struct {
+	start, end int
+	rows       [][]string
+}
+
+// pdfSegment is a run of text in a line, separated from its neighbours by a
+// gap wide enough to be a column gutter.
+type pdfSegment struct {
+	left, right float64
+	text        string
+}
+
+// pageRulings returns the rulings drawn by the path objects of a page.
+// Boxes contribute their four edges, so tables drawn as cell rectangles are
+// found as well as those drawn with lines.
+func (c *PdfConverter) pageRulings(instance pdfium.Pdfium, doc *responses.OpenDocument, pageIdx int) []pdfRuling {
+	page := requests.Page{
+		ByIndex: &requests.PageByIndex{
+			Document: doc.Document,
+			Index:    pageIdx,
+		},
+	}
+	count, err := instance.FPDFPage_CountObjects(&requests.FPDFPage_CountObjects{Page: page})
+	if err != nil {
+		return nil
+	}
+
+	var rulings []pdfRuling
+	for i := 0; i < count.Count; i++ {
+		obj, err := instance.FPDFPage_GetObject(&requests.FPDFPage_GetObject{Page: page, Index: i})
+		if err != nil {
+			continue
+		}
+		typ, err := instance.FPDFPageObj_GetType(&requests.FPDFPageObj_GetType{PageObject: obj.PageObject})
+		if err != nil || typ.Type != enums.FPDF_PAGEOBJ_PATH {
+			continue
+		}
+		b, err := instance.FPDFPageObj_GetBounds(&requests.FPDFPageObj_GetBounds{PageObject: obj.PageObject})
+		if err != nil {
+			continue
+		}
+		left, right := float64(b.Left), float64(b.Right)
+		bottom, top := float64(b.Bottom), float64(b.Top)
+		width, height := right-left, top-bottom
+		switch {
+		case height <= rulingMaxThickness && width >= rulingMinLength:
+			rulings = append(rulings, pdfRuling{horizontal: true, pos: (top + bottom) / 2, from: left, to: right})
+		case width <= rulingMaxThickness && height >= rulingMinLength:
+			rulings = append(rulings, pdfRuling{pos: (left + right) / 2, from: bottom, to: top})
+		case width >= rulingMinLength && height >= rulingMinLength:
+			rulings = append(rulings,
+				pdfRuling{horizontal: true, pos: top, from: left, to: right},
+				pdfRuling{horizontal: true, pos: bottom, from: left, to: right},
+				pdfRuling{pos: left, from: bottom, to: top},
+				pdfRuling{pos: right, from: bottom, to: top})
+		}
+	}
+	return rulings
+}
+
+// detectTables finds tables in the lines of a page: regions ruled into at
+// least two columns by vertical rulings, then runs of lines whose text is
+// split into aligned columns by wide gaps. The tables are returned in line
+// order and do not overlap.
+func detectTables(lines []pdfTextLine, rulings []pdfRuling, bodySize float64) []pdfTable {
+	tables := detectRuledTables(lines, rulings)
+
+	inTable := make([]bool, len(lines))
+	for _, t := range tables {
+		for i := t.start; i < t.end; i++ {
+			inTable[i] = true
+		}
+	}
+	segments := make([][]pdfSegment, len(lines))
+	for i, l := range lines {
+		if !inTable[i] {
+			segments[i] = lineSegments(l, bodySize)
+		}
+	}
+
+	for i := 0; i < len(lines); {
+		end := alignedRun(lines, segments, inTable, i)
+		if end > i {
+			if t, ok := alignedTable(lines, segments, i, end); ok {
+				tables = append(tables, t)
+				i = t.end
+				continue
+			}
+		}
+		i++
+	}
+
+	sort.Slice(tables, func(a, b int) bool { return tables[a].start < tables[b].start })
+	return tables
+}
+
+// detectRuledTables finds runs of lines that lie within the same set of at
+// least three vertical rulings. Rows are split at horizontal rulings when
+// the region has any between its lines, and at every line otherwise.
+func detectRuledTables(lines []pdfTextLine, rulings []pdfRuling) []pdfTable {
+	var verticals, horizontals []pdfRuling
+	for _, r := range rulings {
+		if r.horizontal {
+			horizontals = append(horizontals, r)
+		} else {
+			verticals = append(verticals, r)
+		}
+	}
+	if len(verticals) < 3 {
+		return nil
+	}
+
+	bounds := make([][]float64, len(lines))
+	for i, l := range lines {
+		bounds[i] = lineColumnBounds(l, verticals)
+	}
+
+	var tables []pdfTable
+	for i := 0; i < len(lines); {
+		if len(bounds[i]) < 3 {
+			i++
+			continue
+		}
+		end := i + 1
+		for end < len(lines) && sameBounds(bounds[end], bounds[i]) {
+			end++
+		}
+		if end-i >= 2 {
+			tables = append(tables, ruledTable(lines, horizontals, bounds[i], i, end))
+		}
+		i = end
+	}
+	return tables
+}
+
+// lineColumnBounds returns the x positions of the vertical rulings crossing
+// a line, or nil unless all of its text lies between the outermost ones.
+func lineColumnBounds(l pdfTextLine, verticals []pdfRuling) []float64 {
+	mid := (l.top + l.bottom) / 2
+	var xs []float64
+	for _, v := range verticals {
+		if v.from <= mid && v.to >= mid {
+			xs = append(xs, v.pos)
+		}
+	}
+	sort.Float64s(xs)
+	var bounds []float64
+	for _, x := range xs {
+		if len(bounds) == 0 || x-bounds[len(bounds)-1] > rulingTolerance {
+			bounds = append(bounds, x)
+		}
+	}
+	if len(bounds) < 3 {
+		return nil
+	}
+	for _, r := range l.rects {
+		if r.left < bounds[0]-rulingTolerance || r.right > bounds[len(bounds)-1]+rulingTolerance {
+			return nil
+		}
+	}
+	return bounds
+}
+
+func sameBounds(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > rulingTolerance {
+			return false
+		}
+	}
+	return true
+}
+
+// ruledTable builds the table for lines[start:end], whose columns are
+// delimited by bounds.
+func ruledTable(lines []pdfTextLine, horizontals []pdfRuling, bounds []float64, start, end int) pdfTable {
+	left, right := bounds[0], bounds[len(bounds)-1]
+	ruledBetween := func(upper, lower pdfTextLine) bool {
+		top, bottom := (upper.top+upper.bottom)/2, (lower.top+lower.bottom)/2
+		for _, h := range horizontals {
+			if h.pos < top && h.pos > bottom && h.from <= left+rulingTolerance && h.to >= right-rulingTolerance {
+				return true
+			}
+		}
+		return false
+	}
+	ruledRows := false
+	for i := start + 1; i < end; i++ {
+		if ruledBetween(lines[i-1], lines[i]) {
+			ruledRows = true
+			break
+		}
+	}
+
+	t := pdfTable{start: start, end: end}
+	var row []string
+	for i := start; i < end; i++ {
+		if row == nil || !ruledRows || ruledBetween(lines[i-1], lines[i]) {
+			row = make([]string, len(bounds)-1)
+			t.rows = append(t.rows, row)
+		}
+		for _, r := range lines[i].rects {
+			center := (r.left + r.right) / 2
+			col := sort.SearchFloat64s(bounds[1:], center)
+			col = min(col, len(row)-1)
+			row[col] = joinCellText(row[col], r.text)
+		}
+	}
+	for _, row := range t.rows {
+		for i := range row {
+			row[i] = escapeTableCell(row[i])
+		}
+	}
+	return t
+}
+
+// lineSegments splits a line into runs of text separated by gaps wider than
+// its font size.
+func lineSegments(l pdfTextLine, bodySize float64) []pdfSegment {
+	gutter := l.fontSize
+	if gutter <= 0 {
+		gutter = bodySize
+	}
+	if gutter <= 0 {
+		gutter = 10
+	}
+	var segs []pdfSegment
+	for _, r := range l.rects {
+		if strings.TrimSpace(r.text) == "" {
+			continue
+		}
+		if n := len(segs); n > 0 && r.left-segs[n-1].right <= gutter {
+			segs[n-1].right = max(segs[n-1].right, r.right)
+			segs[n-1].text += r.text
+			continue
+		}
+		segs = append(segs, pdfSegment{left: r.left, right: r.right, text: r.text})
+	}
+	return segs
+}
+
+// alignedRun returns the end of the run of table row candidates starting at
+// lines[start], or start if lines[start] is not one. Candidates have at least
+// two segments; a single-segment line between two candidates is included as
+// a possible continuation of a wrapped cell.
+func alignedRun(lines []pdfTextLine, segments [][]pdfSegment, inTable []bool, start int) int {
+	if inTable[start] || len(segments[start]) < 2 {
+		return start
+	}
+	end := start + 1
+	for end < len(lines) && !inTable[end] && closeLines(lines[end-1], lines[end]) {
+		if len(segments[end]) >= 2 {
+			end++
+			continue
+		}
+		next := end + 1
+		if len(segments[end]) == 1 && next < len(lines) && !inTable[next] &&
+			len(segments[next]) >= 2 && closeLines(lines[end], lines[next]) {
+			end = next + 1
+			continue
+		}
+		break
+	}
+	return end
+}
+
+// closeLines reports whether lower follows upper closely enough to be the
+// next row of the same table.
+func closeLines(upper, lower pdfTextLine) bool {
+	height := upper.top - upper.bottom
+	if height <= 0 {
+		height = upper.fontSize
+	}
+	return upper.bottom-lower.top <= height*maxTableRowGap
+}
+
+// alignedTable validates the row candidates lines[start:end] as a table,
+// dropping trailing lines that do not fit its columns. Columns are the
+// union of the x extents of the segments of multi-segment lines; every such
+// line must put each segment in a different column.
+func alignedTable(lines []pdfTextLine, segments [][]pdfSegment, start, end int) (pdfTable, bool) {
+	for end-start >= minAlignedRows {
+		cols := alignedColumns(segments[start:end])
+		if len(cols) < 2 {
+			return pdfTable{}, false
+		}
+		colOf := func(s pdfSegment) int {
+			for i, c := range cols {
+				if s.left >= c.left-rulingTolerance && s.right <= c.right+rulingTolerance {
+					return i
+				}
+			}
+			return -1
+		}
+
+		t := pdfTable{start: start, end: end}
+		var long, cells int
+		ok := true
+		for i := start; i < end && ok; i++ {
+			if len(segments[i]) == 1 {
+				// Continuation of a wrapped cell in the previous row.
+				col := colOf(segments[i][0])
+				if col < 0 {
+					end = i
+					ok = false
+					break
+				}
+				row := t.rows[len(t.rows)-1]
+				row[col] = joinCellText(row[col], segments[i][0].text)
+				continue
+			}
+			row := make([]string, len(cols))
+			for _, s := range segments[i] {
+				col := colOf(s)
+				if col < 0 || row[col] != "" {
+					return pdfTable{}, false
+				}
+				row[col] = strings.TrimSpace(s.text)
+			}
+			t.rows = append(t.rows, row)
+		}
+		if !ok {
+			continue
+		}
+		if len(t.rows) < minAlignedRows {
+			return pdfTable{}, false
+		}
+		for _, row := range t.rows {
+			for i, cell := range row {
+				if cell == "" {
+					continue
+				}
+				cells++
+				if utf8.RuneCountInString(cell) > maxCellRunes {
+					long++
+				}
+				row[i] = escapeTableCell(cell)
+			}
+		}
+		if long*2 > cells {
+			return pdfTable{}, false
+		}
+		return t, true
+	}
+	return pdfTable{}, false
+}
+
+// alignedColumns merges the overlapping x extents of the segments of the
+// multi-segment lines into columns, left to right.
+func alignedColumns(segments [][]pdfSegment) []pdfSegment {
+	var all []pdfSegment
+	for _, segs := range segments {
+		if len(segs) >= 2 {
+			all = append(all, segs...)
+		}
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].left < all[j].left })
+	var cols []pdfSegment
+	for _, s := range all {
+		if n := len(cols); n > 0 && s.left <= cols[n-1].right {
+			cols[n-1].right = max(cols[n-1].right, s.right)
+			continue
+		}
+		cols = append(cols, pdfSegment{left: s.left, right: s.right})
+	}
+	return cols
+}
+
+func joinCellText(cell, text string) string {
+	text = strings.TrimSpace(text)
+	if cell == "" || text == "" {
+		return cell + text
+	}
+	return cell + " " + text
+}
+
+// escapeTableCell makes text safe for a Markdown table cell.
+func escapeTableCell(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	return strings.ReplaceAll(text, "|", `\|`)
+}
@@ -50,6 +50,10 @@ func (c *PlainTextConverter) Accepts(info StreamInfo) bool {
 	return false
 }
 
+func (c *PlainTextConverter) Formats() (extensions, mimeTypes []string) {
+	return []string{".txt", ".text", ".md", ".markdown", ".json", ".jsonl"}, []string{"text/plain", "text/markdown", "application/json", "application/markdown"}
+}
+
 func (c *PlainTextConverter) Convert(reader io.ReadSeeker, info StreamInfo) (*DocumentConverterResult, error) {
 	data, err := io.ReadAll(reader)
 	if err != nil {
@@ -14,12 +14,14 @@ package markitdown
 import (
 	"archive/zip"
 	"bytes"
+	"context"
 	"encoding/xml"
 	"fmt"
 	"io"
 	"math"
 	"path"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/conductor-oss/markitdown/internal/ooxml"
@@ -43,7 +45,16 @@ func (c *PptxConverter) Accepts(info StreamInfo) bool {
 	return strings.HasPrefix(mime, "application/vnd.openxmlformats-officedocument.presentationml")
 }
 
+func (c *PptxConverter) Formats() (extensions, mimeTypes []string) {
+	return []string{".pptx"}, []string{"application/vnd.openxmlformats-officedocument.presentationml.presentation"}
+}
+
 func (c *PptxConverter) Convert(reader io.ReadSeeker, info StreamInfo) (*DocumentConverterResult, error) {
+	return c.ConvertContext(context.Background(), reader, info)
+}
+
+// ConvertContext converts a PPTX, checking ctx between slides.
+func (c *PptxConverter) ConvertContext(ctx context.Context, reader io.ReadSeeker, info StreamInfo) (*DocumentConverterResult, error) {
 	data, err := io.ReadAll(reader)
 	if err != nil {
 		return nil, fmt.Errorf("read PPTX: %w", err)
@@ -53,6 +64,9 @@ func (c *PptxConverter) Convert(reader io.ReadSeeker, info StreamInfo) (*Documen
 	if err != nil {
 		return nil, fmt.Errorf("open PPTX ZIP: %w", err)
 	}
+	if err := checkArchive(ctx, zr); err != nil {
+		return nil, err
+	}
 
 	// Get slide order from presentation.xml
 	slideOrder, err := c.getSlideOrder(zr)
@@ -61,17 +75,26 @@ func (c *PptxConverter) Convert(reader io.ReadSeeker, info StreamInfo) (*Documen
 	}
 
 	var md strings.Builder
+	result := &DocumentConverterResult{}
 
 	for slideNum, slidePath := range slideOrder {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		md.WriteString(fmt.Sprintf("\n\n<!-- Slide number: %d -->\n", slideNum+1))
 
 		slideData, err := ooxml.ReadFileFromZip(zr, slidePath)
 		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("slide %d skipped: %v", slideNum+1, err))
 			continue
 		}
 
-		slideContent := c.parseSlide(slideData)
-		md.WriteString(slideContent)
+		var slideMd strings.Builder
+		slideContent, err := c.parseSlide(ctx, zr, slidePath, slideData)
+		if err != nil {
+			return nil, err
+		}
+		slideMd.WriteString(slideContent)
 
 		// Check for notes
 		notesPath := c.getNotesPath(slidePath, zr)
@@ -80,16 +103,26 @@ func (c *PptxConverter) Convert(reader io.ReadSeeker, info StreamInfo) (*Documen
 			if err == nil {
 				notes := c.extractNotesText(notesData)
 				if strings.TrimSpace(notes) != "" {
-					md.WriteString("\n\n### Notes:\n")
-					md.WriteString(notes)
+					slideMd.WriteString("\n\n### Notes:\n")
+					slideMd.WriteString(notes)
 				}
 			}
 		}
+
+		md.WriteString(slideMd.String())
+		result.Segments = append(result.Segments, Segment{
+			Kind:     SegmentSlide,
+			Number:   slideNum + 1,
+			Name:     slidePath,
+			Markdown: slideMd.String(),
+		})
 	}
 
-	return &DocumentConverterResult{
-		Markdown: strings.TrimSpace(md.String()),
-	}, nil
+	setDocProperties(result, zr)
+	result.setMetadata(MetadataPageCount, strconv.Itoa(len(slideOrder)))
+
+	result.Markdown = strings.TrimSpace(md.String())
+	return result, nil
 }
 
 // getSlideOrder returns slide file paths in presentation order.
@@ -148,11 +181,15 @@ type pptxShape struct {
 	table   [][]string
 	isPic   bool
 	altText string
+	imageID string // relationship ID of the picture's image part
 }
 
 // parseSlide parses a slide XML and extracts shapes, then formats as markdown.
-func (c *PptxConverter) parseSlide(slideData []byte) string {
+// With an asset writer, pictures are stored and linked; otherwise pictures
+// with alt text are shown as placeholders.
+func (c *PptxConverter) parseSlide(ctx context.Context, zr *zip.Reader, slidePath string, slideData []byte) (string, error) {
 	shapes := c.extractShapes(slideData)
+	var rels map[string]ooxml.Relationship
 
 	sort.SliceStable(shapes, func(i, j int) bool {
 		if shapes[i].top != shapes[j].top {
@@ -163,9 +200,30 @@ func (c *PptxConverter) parseSlide(slideData []byte) string {
 
 	var md strings.Builder
 	for _, shape := range shapes {
-		if shape.isPic && shape.altText != "" {
-			// Picture with alt text
-			md.WriteString(fmt.Sprintf("\n![%s](image)\n", sanitizeAltText(shape.altText)))
+		if shape.isPic {
+			alt, link := sanitizeAltText(shape.altText), "image"
+			if m := c.markitdown; m != nil && (m.assetWriter != nil || m.imageDescriber != nil) && shape.imageID != "" {
+				if rels == nil {
+					rels, _ = ooxml.ParseRelationshipsFromReader(zr, ooxml.RelsPathFor(slidePath))
+				}
+				if rel, ok := rels[shape.imageID]; ok {
+					target := ooxml.ResolveTarget(slidePath, rel.Target)
+					if data, err := ooxml.ReadFileFromZip(zr, target); err == nil {
+						mediaType := imageMediaType(target)
+						if alt, err = m.describeImage(ctx, alt, target, mediaType, data); err != nil {
+							return "", err
+						}
+						if m.assetWriter != nil {
+							if link, err = m.saveAsset(ctx, mediaType, data); err != nil {
+								return "", err
+							}
+						}
+					}
+				}
+			}
+			if link != "image" || alt != "" {
+				md.WriteString(fmt.Sprintf("\n![%s](%s)\n", alt, link))
+			}
 		} else if shape.isTable && len(shape.table) > 0 {
 			md.WriteString(c.tableToMarkdown(shape.table))
 		} else if shape.isTitle {
@@ -178,7 +236,7 @@ func (c *PptxConverter) parseSlide(slideData []byte) string {
 		}
 	}
 
-	return md.String()
+	return md.String(), nil
 }
 
 // sanitizeAltText cleans alt text for markdown image syntax.
@@ -371,12 +429,14 @@ func (c *PptxConverter) extractPic(node *xmlNode) *pptxShape {
 		}
 	}
 
-	c.extractPosition(node, shape)
-
-	if shape.altText == "" {
-		return nil
+	if blipFill := node.findChild("blipFill"); blipFill != nil {
+		if blip := blipFill.findChild("blip"); blip != nil {
+			shape.imageID = blip.getAttr("embed")
+		}
 	}
 
+	c.extractPosition(node, shape)
+
 	return shape
 }
 
@@ -49,6 +49,10 @@ func (c *RSSConverter) Accepts(info StreamInfo) bool {
 	return false
 }
 
+func (c *RSSConverter) Formats() (extensions, mimeTypes []string) {
+	return []string{".rss", ".atom", ".xml"}, []string{"application/rss+xml", "application/atom+xml", "text/xml", "application/xml"}
+}
+
 func (c *RSSConverter) Convert(reader io.ReadSeeker, info StreamInfo) (*DocumentConverterResult, error) {
 	fp := gofeed.NewParser()
 	feed, err := fp.Parse(reader)
@@ -105,8 +109,20 @@ func (c *RSSConverter) Convert(reader io.ReadSeeker, info StreamInfo) (*Document
 		b.WriteString("\n")
 	}
 
-	return &DocumentConverterResult{
+	result := &DocumentConverterResult{
 		Markdown: b.String(),
 		Title:    title,
-	}, nil
+	}
+	result.setMetadata(MetadataTitle, feed.Title)
+	var authors []string
+	for _, a := range feed.Authors {
+		if a != nil && a.Name != "" {
+			authors = append(authors, a.Name)
+		}
+	}
+	result.setMetadata(MetadataAuthor, strings.Join(authors, ", "))
+	result.setMetadata(MetadataLanguage, feed.Language)
+	result.setMetadata(MetadataCreated, feed.Published)
+	result.setMetadata(MetadataModified, feed.Updated)
+	return result, nil
 }
@@ -36,6 +36,10 @@ func (c *XlsConverter) Accepts(info StreamInfo) bool {
 	return strings.HasPrefix(mime, "application/vnd.ms-excel")
 }
 
+func (c *XlsConverter) Formats() (extensions, mimeTypes []string) {
+	return []string{".xls"}, []string{"application/vnd.ms-excel"}
+}
+
 func (c *XlsConverter) Convert(reader io.ReadSeeker, info StreamInfo) (*DocumentConverterResult, error) {
 	// extrame/xls requires a file path, so we need to write to a temp file
 	tmpFile, err := os.CreateTemp("", "markitdown-*.xls")
@@ -57,6 +61,7 @@ func (c *XlsConverter) Convert(reader io.ReadSeeker, info StreamInfo) (*Document
 	}
 
 	var md strings.Builder
+	result := &DocumentConverterResult{}
 
 	for i := 0; i < wb.NumSheets(); i++ {
 		sheet := wb.GetSheet(i)
@@ -90,12 +95,19 @@ func (c *XlsConverter) Convert(reader io.ReadSeeker, info StreamInfo) (*Document
 			continue
 		}
 
+		table := renderMarkdownTable(rows)
 		fmt.Fprintf(&md, "## %s\n", sheetName)
-		md.WriteString(renderMarkdownTable(rows))
+		md.WriteString(table)
 		md.WriteString("\n")
+
+		result.Segments = append(result.Segments, Segment{
+			Kind:     SegmentSheet,
+			Number:   i + 1,
+			Name:     sheetName,
+			Markdown: table,
+		})
 	}
 
-	return &DocumentConverterResult{
-		Markdown: md.String(),
-	}, nil
+	result.Markdown = md.String()
+	return result, nil
 }
@@ -12,6 +12,9 @@
 package markitdown
 
 import (
+	"archive/zip"
+	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"strings"
@@ -20,13 +23,21 @@ import (
 )
 
 // XlsxConverter handles XLSX files.
-type XlsxConverter struct{}
+type XlsxConverter struct {
+	markitdown *MarkItDown
+}
 
 // NewXlsxConverter creates a new XlsxConverter.
 func NewXlsxConverter() *XlsxConverter {
 	return &XlsxConverter{}
 }
 
+// NewXlsxConverterFor creates an XlsxConverter that stores embedded images
+// with m's AssetWriter.
+func NewXlsxConverterFor(m *MarkItDown) *XlsxConverter {
+	return &XlsxConverter{markitdown: m}
+}
+
 func (c *XlsxConverter) Accepts(info StreamInfo) bool {
 	if info.Extension == ".xlsx" {
 		return true
@@ -35,22 +46,53 @@ func (c *XlsxConverter) Accepts(info StreamInfo) bool {
 	return strings.HasPrefix(mime, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
 }
 
+func (c *XlsxConverter) Formats() (extensions, mimeTypes []string) {
+	return []string{".xlsx"}, []string{"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"}
+}
+
 func (c *XlsxConverter) Convert(reader io.ReadSeeker, info StreamInfo) (*DocumentConverterResult, error) {
-	f, err := excelize.OpenReader(reader)
+	return c.ConvertContext(context.Background(), reader, info)
+}
+
+// ConvertContext converts an XLSX workbook, checking ctx between sheets.
+func (c *XlsxConverter) ConvertContext(ctx context.Context, reader io.ReadSeeker, info StreamInfo) (*DocumentConverterResult, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("read XLSX: %w", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("open XLSX ZIP: %w", err)
+	}
+	if err := checkArchive(ctx, zr); err != nil {
+		return nil, err
+	}
+
+	f, err := excelize.OpenReader(bytes.NewReader(data))
 	if err != nil {
 		return nil, fmt.Errorf("open XLSX: %w", err)
 	}
 	defer f.Close()
 
 	var md strings.Builder
+	result := &DocumentConverterResult{}
 	sheets := f.GetSheetList()
 
-	for _, sheet := range sheets {
+	for i, sheet := range sheets {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		rows, err := f.GetRows(sheet)
 		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("sheet %q skipped: %v", sheet, err))
 			continue
 		}
-		if len(rows) == 0 {
+		images, err := c.sheetImages(ctx, f, sheet)
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == 0 && images == "" {
 			continue
 		}
 
@@ -58,12 +100,52 @@ func (c *XlsxConverter) Convert(reader io.ReadSeeker, info StreamInfo) (*Documen
 		fmt.Fprintf(&md, "## %s\n", sheet)
 
 		// Render as markdown table
-		table := renderMarkdownTable(rows)
+		table := renderMarkdownTable(rows) + images
 		md.WriteString(table)
 		md.WriteString("\n")
+
+		result.Segments = append(result.Segments, Segment{
+			Kind:     SegmentSheet,
+			Number:   i + 1,
+			Name:     sheet,
+			Markdown: table,
+		})
 	}
 
-	return &DocumentConverterResult{
-		Markdown: md.String(),
-	}, nil
+	setDocProperties(result, zr)
+
+	result.Markdown = md.String()
+	return result, nil
+}
+
+// sheetImages stores the pictures anchored in a sheet with the asset writer
+// and returns Markdown image links for them, in cell order. Without an asset
+// writer pictures are omitted.
+func (c *XlsxConverter) sheetImages(ctx context.Context, f *excelize.File, sheet string) (string, error) {
+	if c.markitdown == nil || c.markitdown.assetWriter == nil {
+		return "", nil
+	}
+	cells, err := f.GetPictureCells(sheet)
+	if err != nil {
+		return "", nil
+	}
+	var md strings.Builder
+	for _, cell := range cells {
+		pics, err := f.GetPictures(sheet, cell)
+		if err != nil {
+			continue
+		}
+		for _, pic := range pics {
+			link, err := c.markitdown.saveAsset(ctx, imageMediaType(pic.Extension), pic.File)
+			if err != nil {
+				return "", err
+			}
+			alt := ""
+			if pic.Format != nil {
+				alt = sanitizeAltText(pic.Format.AltText)
+			}
+			fmt.Fprintf(&md, "\n![%s](%s)\n", alt, link)
+		}
+	}
+	return md.String(), nil
 }
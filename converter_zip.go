@@ -14,6 +14,7 @@ package markitdown
 import (
 	"archive/zip"
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"path/filepath"
@@ -38,7 +39,16 @@ func (c *ZipConverter) Accepts(info StreamInfo) bool {
 	return strings.HasPrefix(mime, "application/zip")
 }
 
+func (c *ZipConverter) Formats() (extensions, mimeTypes []string) {
+	return []string{".zip"}, []string{"application/zip"}
+}
+
 func (c *ZipConverter) Convert(reader io.ReadSeeker, info StreamInfo) (*DocumentConverterResult, error) {
+	return c.ConvertContext(context.Background(), reader, info)
+}
+
+// ConvertContext converts each entry of the archive, checking ctx between entries.
+func (c *ZipConverter) ConvertContext(ctx context.Context, reader io.ReadSeeker, info StreamInfo) (*DocumentConverterResult, error) {
 	data, err := io.ReadAll(reader)
 	if err != nil {
 		return nil, fmt.Errorf("read ZIP: %w", err)
@@ -48,6 +58,13 @@ func (c *ZipConverter) Convert(reader io.ReadSeeker, info StreamInfo) (*Document
 	if err != nil {
 		return nil, fmt.Errorf("open ZIP: %w", err)
 	}
+	if err := checkArchive(ctx, zr); err != nil {
+		return nil, err
+	}
+	ctx, err = enterArchive(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	var md strings.Builder
 	filename := info.Filename
@@ -55,20 +72,28 @@ func (c *ZipConverter) Convert(reader io.ReadSeeker, info StreamInfo) (*Document
 		filename = "archive"
 	}
 	md.WriteString(fmt.Sprintf("Content from the zip file `%s`:\n\n", filename))
+	result := &DocumentConverterResult{}
 
+	entryNum := 0 // position among the archive's files, skipped ones included
 	for _, f := range zr.File {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		if f.FileInfo().IsDir() {
 			continue
 		}
+		entryNum++
 
 		rc, err := f.Open()
 		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("ZIP entry %s skipped: %v", f.Name, err))
 			continue
 		}
 
 		fileData, err := io.ReadAll(rc)
 		rc.Close()
 		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("ZIP entry %s skipped: %v", f.Name, err))
 			continue
 		}
 
@@ -84,20 +109,35 @@ func (c *ZipConverter) Convert(reader io.ReadSeeker, info StreamInfo) (*Document
 		_, _ = fileReader.Seek(0, io.SeekStart)
 
 		// Try to convert
-		result, err := c.markitdown.ConvertReader(fileReader, fileInfo)
+		entry, err := c.markitdown.ConvertReaderContext(ctx, fileReader, fileInfo)
 		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			if IsLimitExceeded(err) {
+				return nil, err
+			}
 			// Skip files that can't be converted
+			result.Warnings = append(result.Warnings, fmt.Sprintf("ZIP entry %s skipped: %v", f.Name, err))
 			continue
 		}
+		for _, w := range entry.Warnings {
+			result.Warnings = append(result.Warnings, f.Name+": "+w)
+		}
 
-		if strings.TrimSpace(result.Markdown) != "" {
+		if strings.TrimSpace(entry.Markdown) != "" {
 			md.WriteString(fmt.Sprintf("## File: %s\n", f.Name))
-			md.WriteString(result.Markdown)
+			md.WriteString(entry.Markdown)
 			md.WriteString("\n\n")
+			result.Segments = append(result.Segments, Segment{
+				Kind:     SegmentEntry,
+				Number:   entryNum,
+				Name:     f.Name,
+				Markdown: entry.Markdown,
+			})
 		}
 	}
 
-	return &DocumentConverterResult{
-		Markdown: md.String(),
-	}, nil
+	result.Markdown = md.String()
+	return result, nil
 }
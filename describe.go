@@ -0,0 +1,85 @@
+// Copyright 2026 Conductor OSS
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+
+package markitdown
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// ImageDescriber generates text descriptions of images, such as captions from
+// a vision model. Set one with WithImageDescriber; the describe package has
+// an OpenAI-compatible implementation and a fake for tests.
+type ImageDescriber interface {
+	// Describe returns a description of image, whose media type is
+	// mediaType (e.g. "image/png").
+	Describe(ctx context.Context, image []byte, mediaType string, hints ImageHints) (string, error)
+}
+
+// ImageHints gives an ImageDescriber context about the image to describe.
+type ImageHints struct {
+	// AltText is the image's existing alt text, if any. It is often just the
+	// file name.
+	AltText string
+	// Name is the image's file name or path within the document, if known.
+	Name string
+}
+
+// placeholderAlt reports whether alt text carries no description: it is
+// empty, or just the image's file name.
+func placeholderAlt(alt, name string) bool {
+	alt = strings.TrimSpace(alt)
+	if alt == "" {
+		return true
+	}
+	if name != "" && alt == path.Base(name) {
+		return true
+	}
+	return imageMediaType(alt) != "" && !strings.ContainsAny(alt, " \t")
+}
+
+// describeImage returns a generated description for an image whose alt text
+// is missing or just a file name, and alt unchanged otherwise or without an
+// ImageDescriber. Each distinct image is described once per conversion.
+// Describer failures are recorded as conversion warnings and keep alt.
+func (m *MarkItDown) describeImage(ctx context.Context, alt, name, mediaType string, data []byte) (string, error) {
+	if m.imageDescriber == nil || !placeholderAlt(alt, name) {
+		return alt, nil
+	}
+	st := stateFrom(ctx)
+	sum := sha256.Sum256(data)
+	desc, ok := st.descriptions[sum]
+	if !ok {
+		var err error
+		desc, err = m.imageDescriber.Describe(ctx, data, mediaType, ImageHints{AltText: alt, Name: name})
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return "", ctxErr
+			}
+			if name != "" {
+				st.warn(fmt.Sprintf("describe image %s: %v", name, err))
+			} else {
+				st.warn(fmt.Sprintf("describe %s image: %v", mediaType, err))
+			}
+			desc = ""
+		}
+		desc = sanitizeAltText(desc)
+		st.descriptions[sum] = desc // failures too, so they are not retried
+	}
+	if desc == "" {
+		return alt, nil
+	}
+	return desc, nil
+}
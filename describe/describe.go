@@ -0,0 +1,172 @@
+// Copyright 2026 Conductor OSS
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+
+// Package describe provides markitdown.ImageDescriber implementations: OpenAI
+// for any OpenAI-compatible chat completions API with vision support, and
+// Fake for tests.
+//
+//	m := markitdown.New(markitdown.WithImageDescriber(&describe.OpenAI{
+//		BaseURL: "https://api.openai.com/v1",
+//		APIKey:  os.Getenv("OPENAI_API_KEY"),
+//		Model:   "gpt-4o-mini",
+//	}))
+package describe
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	markitdown "github.com/conductor-oss/markitdown"
+)
+
+// DefaultPrompt is the instruction sent with each image when OpenAI.Prompt is
+// empty.
+const DefaultPrompt = "Write a detailed caption for this image."
+
+// maxErrorBody bounds how much of an error response is quoted in errors.
+const maxErrorBody = 512
+
+// OpenAI describes images with an OpenAI-compatible chat completions API
+// (POST {BaseURL}/chat/completions), sending each image as a data URI.
+type OpenAI struct {
+	// BaseURL is the API root, e.g. "https://api.openai.com/v1".
+	BaseURL string
+	// APIKey is sent as a bearer token, if set.
+	APIKey string
+	// Model is the vision-capable model to use.
+	Model string
+	// Prompt is the instruction sent with each image (default DefaultPrompt).
+	Prompt string
+	// HTTPClient sends the requests (default http.DefaultClient).
+	HTTPClient *http.Client
+}
+
+type chatRequest struct {
+	Model    string        `json:"model"`
+	Messages []chatMessage `json:"messages"`
+}
+
+type chatMessage struct {
+	Role    string        `json:"role"`
+	Content []chatContent `json:"content"`
+}
+
+type chatContent struct {
+	Type     string    `json:"type"`
+	Text     string    `json:"text,omitempty"`
+	ImageURL *imageURL `json:"image_url,omitempty"`
+}
+
+type imageURL struct {
+	URL string `json:"url"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+	} `json:"choices"`
+}
+
+// Describe implements markitdown.ImageDescriber.
+func (o *OpenAI) Describe(ctx context.Context, image []byte, mediaType string, hints markitdown.ImageHints) (string, error) {
+	prompt := o.Prompt
+	if prompt == "" {
+		prompt = DefaultPrompt
+	}
+	body, err := json.Marshal(chatRequest{
+		Model: o.Model,
+		Messages: []chatMessage{{
+			Role: "user",
+			Content: []chatContent{
+				{Type: "text", Text: prompt},
+				{Type: "image_url", ImageURL: &imageURL{
+					URL: "data:" + mediaType + ";base64," + base64.StdEncoding.EncodeToString(image),
+				}},
+			},
+		}},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(o.BaseURL, "/")+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if o.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+o.APIKey)
+	}
+	client := o.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return "", fmt.Errorf("chat completions: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	var out chatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", fmt.Errorf("decode chat completions response: %w", err)
+	}
+	if len(out.Choices) == 0 {
+		return "", errors.New("chat completions: no choices in response")
+	}
+	return strings.TrimSpace(out.Choices[0].Message.Content), nil
+}
+
+// Call records one Fake.Describe call.
+type Call struct {
+	Image     []byte
+	MediaType string
+	Hints     markitdown.ImageHints
+}
+
+// Fake is an ImageDescriber for tests. It returns Description, or Err if
+// set, and records every call. It is safe for concurrent use.
+type Fake struct {
+	Description string
+	Err         error
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+// Describe implements markitdown.ImageDescriber.
+func (f *Fake) Describe(ctx context.Context, image []byte, mediaType string, hints markitdown.ImageHints) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, Call{Image: image, MediaType: mediaType, Hints: hints})
+	return f.Description, f.Err
+}
+
+// Calls returns the calls made so far.
+func (f *Fake) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Call(nil), f.calls...)
+}
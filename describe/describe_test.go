@@ -0,0 +1,99 @@
+// Copyright 2026 Conductor OSS
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+
+package describe
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	markitdown "github.com/conductor-oss/markitdown"
+)
+
+func TestOpenAI(t *testing.T) {
+	var got chatRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" || r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, "bad request", http.StatusUnauthorized)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":" A red square. "}}]}`))
+	}))
+	defer srv.Close()
+
+	d := &OpenAI{BaseURL: srv.URL + "/v1/", APIKey: "secret", Model: "vision-1"}
+	desc, err := d.Describe(context.Background(), []byte("img"), "image/png", markitdown.ImageHints{})
+	if err != nil {
+		t.Fatalf("Describe error: %v", err)
+	}
+	if desc != "A red square." {
+		t.Errorf("description = %q", desc)
+	}
+	if got.Model != "vision-1" || len(got.Messages) != 1 || len(got.Messages[0].Content) != 2 {
+		t.Fatalf("unexpected request: %+v", got)
+	}
+	if c := got.Messages[0].Content; c[0].Text != DefaultPrompt || c[1].ImageURL == nil || c[1].ImageURL.URL != "data:image/png;base64,aW1n" {
+		t.Errorf("unexpected content: %+v", c)
+	}
+
+	d.APIKey = "wrong"
+	if _, err := d.Describe(context.Background(), []byte("img"), "image/png", markitdown.ImageHints{}); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("expected 401 error, got %v", err)
+	}
+}
+
+func TestImageDescriber(t *testing.T) {
+	const (
+		red  = "data:image/png;base64,cmVk"
+		blue = "data:image/png;base64,Ymx1ZQ=="
+	)
+	html := `<html><body>` +
+		`<img src="` + red + `">` +
+		`<img src="` + red + `" alt="image1.png">` +
+		`<img src="` + blue + `" alt="Quarterly revenue chart">` +
+		`</body></html>`
+
+	fake := &Fake{Description: "A [red] square\non white"}
+	m := markitdown.New(markitdown.WithImageDescriber(fake), markitdown.WithKeepDataURIs(true))
+	result, err := m.ConvertReader(strings.NewReader(html), markitdown.StreamInfo{Extension: ".html"})
+	if err != nil {
+		t.Fatalf("ConvertReader error: %v", err)
+	}
+	if n := strings.Count(result.Markdown, "![A red square on white]("+red+")"); n != 2 {
+		t.Errorf("expected 2 described images:\n%s", result.Markdown)
+	}
+	if !strings.Contains(result.Markdown, "![Quarterly revenue chart]("+blue+")") {
+		t.Errorf("existing alt text was replaced:\n%s", result.Markdown)
+	}
+	if calls := fake.Calls(); len(calls) != 1 || string(calls[0].Image) != "red" || calls[0].MediaType != "image/png" {
+		t.Errorf("calls = %+v, want one call for the red image", calls)
+	}
+
+	failing := &Fake{Err: errors.New("model unavailable")}
+	m = markitdown.New(markitdown.WithImageDescriber(failing))
+	result, err = m.ConvertReader(strings.NewReader(html), markitdown.StreamInfo{Extension: ".html"})
+	if err != nil {
+		t.Fatalf("ConvertReader error: %v", err)
+	}
+	if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "model unavailable") {
+		t.Errorf("warnings = %q, want the describer error", result.Warnings)
+	}
+}
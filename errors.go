@@ -70,3 +70,54 @@ func IsUnsupportedFormat(err error) bool {
 	var target *UnsupportedFormatError
 	return errors.As(err, &target)
 }
+
+// PasswordRequiredError is returned when a document is encrypted and the
+// password from StreamInfo.Password, ContextWithPassword or WithPassword is
+// missing or does not open it.
+type PasswordRequiredError struct {
+	// Incorrect is set when a password was given but is wrong.
+	Incorrect bool
+}
+
+func (e *PasswordRequiredError) Error() string {
+	if e.Incorrect {
+		return "incorrect document password"
+	}
+	return "document is password protected"
+}
+
+// IsPasswordRequired reports whether the error is a PasswordRequiredError.
+func IsPasswordRequired(err error) bool {
+	var target *PasswordRequiredError
+	return errors.As(err, &target)
+}
+
+// Limit names a resource limit configured on MarkItDown.
+type Limit string
+
+// Resource limits that can be exceeded.
+const (
+	LimitInputBytes        Limit = "input bytes"
+	LimitDecompressedBytes Limit = "decompressed bytes"
+	LimitArchiveEntries    Limit = "archive entries"
+	LimitNestingDepth      Limit = "nesting depth"
+	LimitPages             Limit = "pages"
+)
+
+// LimitExceededError is returned when an input exceeds a configured
+// resource limit. Conversion stops as soon as a limit is hit.
+type LimitExceededError struct {
+	Limit  Limit
+	Max    int64
+	Actual int64 // lower bound when the input was not read in full
+}
+
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("%s limit exceeded: %d > %d", e.Limit, e.Actual, e.Max)
+}
+
+// IsLimitExceeded reports whether the error is a LimitExceededError.
+func IsLimitExceeded(err error) bool {
+	var target *LimitExceededError
+	return errors.As(err, &target)
+}
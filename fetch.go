@@ -0,0 +1,311 @@
+// Copyright 2026 Conductor OSS
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+
+package markitdown
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"path"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Defaults for ConvertURL.
+const (
+	DefaultUserAgent        = "markitdown-go"
+	DefaultHTTPTimeout      = 60 * time.Second
+	DefaultMaxResponseBytes = 100 << 20
+)
+
+// ErrHostNotAllowed is returned by ConvertURL when the HostPolicy rejects
+// the URL's host or an address it resolves to, including on redirects.
+var ErrHostNotAllowed = errors.New("host not allowed")
+
+// HostPolicy restricts the hosts ConvertURL may fetch from. The zero value
+// allows any public host and rejects private, loopback, link-local and other
+// non-public addresses, which protects servers that convert user-supplied
+// URLs against server-side request forgery. Unless AllowPrivate is set,
+// ConvertURL connects directly, ignoring the Proxy of the client's
+// *http.Transport and the HTTP_PROXY and HTTPS_PROXY environment variables,
+// so that the address check applies to the real destination. Clients with
+// another RoundTripper have each host resolved and checked before the
+// request, which does not guard against DNS rebinding; use an
+// *http.Transport where that matters.
+type HostPolicy struct {
+	// Allow, when non-empty, lists the only hosts that may be fetched.
+	// Entries match a host exactly or, with a "*." prefix, any subdomain.
+	Allow []string
+	// Deny lists hosts that may never be fetched, with the same syntax.
+	// Deny takes precedence over Allow.
+	Deny []string
+	// AllowPrivate permits non-public addresses such as 127.0.0.1,
+	// 10.0.0.0/8 or 169.254.169.254.
+	AllowPrivate bool
+}
+
+// checkHost applies the allow and deny lists to a host name or IP literal.
+func (p HostPolicy) checkHost(host string) error {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if matchHost(p.Deny, host) {
+		return fmt.Errorf("%w: %s is denied", ErrHostNotAllowed, host)
+	}
+	if len(p.Allow) > 0 && !matchHost(p.Allow, host) {
+		return fmt.Errorf("%w: %s is not in the allow list", ErrHostNotAllowed, host)
+	}
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return p.checkAddr(addr)
+	}
+	return nil
+}
+
+// checkAddr rejects non-public addresses unless AllowPrivate is set.
+func (p HostPolicy) checkAddr(addr netip.Addr) error {
+	if p.AllowPrivate || isPublicAddr(addr) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s is not a public address", ErrHostNotAllowed, addr)
+}
+
+// checkResolved resolves host and checks each of its addresses.
+func (p HostPolicy) checkResolved(ctx context.Context, host string) error {
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return err
+	}
+	for _, addr := range addrs {
+		if err := p.checkAddr(addr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func matchHost(patterns []string, host string) bool {
+	for _, p := range patterns {
+		p = strings.ToLower(strings.TrimSuffix(p, "."))
+		if suffix, ok := strings.CutPrefix(p, "*."); ok {
+			if strings.HasSuffix(host, "."+suffix) {
+				return true
+			}
+			continue
+		}
+		if host == p {
+			return true
+		}
+	}
+	return false
+}
+
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598).
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+func isPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if addr.Is4() && addr.As4()[0] == 0 { // 0.0.0.0/8, "this network"
+		return false
+	}
+	return addr.IsGlobalUnicast() &&
+		!addr.IsPrivate() &&
+		!sharedAddressSpace.Contains(addr)
+}
+
+// newFetchClient returns the client used by ConvertURL: the configured
+// client (or a default one with a timeout) whose redirects and connections
+// are checked against the host policy.
+func (m *MarkItDown) newFetchClient() *http.Client {
+	var client http.Client
+	if m.httpClient != nil {
+		client = *m.httpClient
+	} else {
+		client.Timeout = DefaultHTTPTimeout
+	}
+
+	policy := m.hostPolicy
+	checkRedirect := client.CheckRedirect
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if err := checkFetchURL(policy, req.URL); err != nil {
+			return err
+		}
+		if checkRedirect != nil {
+			return checkRedirect(req, via)
+		}
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	}
+
+	if policy.AllowPrivate {
+		return &client
+	}
+
+	// Check the addresses actually dialed so that DNS answers cannot
+	// smuggle in a private address. Custom transports dial on their own, so
+	// the host of each request they send, redirects included, is resolved
+	// and checked beforehand instead.
+	var transport *http.Transport
+	switch t := client.Transport.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		transport = t.Clone()
+	default:
+		client.Transport = &checkedTransport{base: t, policy: policy}
+	}
+	if transport != nil {
+		dialer := &net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+			Control: func(network, address string, _ syscall.RawConn) error {
+				ap, err := netip.ParseAddrPort(address)
+				if err != nil {
+					return fmt.Errorf("%w: %s", ErrHostNotAllowed, address)
+				}
+				return policy.checkAddr(ap.Addr())
+			},
+		}
+		transport.DialContext = dialer.DialContext
+		transport.Proxy = nil // a proxy would dial on our behalf, bypassing the check
+		client.Transport = transport
+	}
+	return &client
+}
+
+// checkedTransport rejects requests to hosts that resolve to an address
+// the policy does not allow before passing them to base. Unlike the dial
+// check, it cannot prevent a host from resolving differently when base
+// connects.
+type checkedTransport struct {
+	base   http.RoundTripper
+	policy HostPolicy
+}
+
+func (t *checkedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.policy.checkResolved(req.Context(), req.URL.Hostname()); err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
+
+// checkFetchURL validates a URL's scheme and host against the policy.
+func checkFetchURL(policy HostPolicy, u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported URL scheme %q", u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return fmt.Errorf("URL %q has no host", u.String())
+	}
+	return policy.checkHost(u.Hostname())
+}
+
+// acceptHeader lists the MIME types of the registered converters, most
+// specific first, followed by a low-priority wildcard.
+func (m *MarkItDown) acceptHeader() string {
+	seen := map[string]bool{}
+	var types []string
+	for _, f := range m.Formats() {
+		for _, t := range f.MIMETypes {
+			if !seen[t] {
+				seen[t] = true
+				types = append(types, t)
+			}
+		}
+	}
+	return strings.Join(append(types, "*/*;q=0.1"), ", ")
+}
+
+// fetch downloads rawURL and describes the response as a StreamInfo.
+func (m *MarkItDown) fetch(ctx context.Context, rawURL string) (*bytes.Reader, StreamInfo, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, StreamInfo{}, fmt.Errorf("fetch URL: %w", err)
+	}
+	if err := checkFetchURL(m.hostPolicy, u); err != nil {
+		return nil, StreamInfo{}, fmt.Errorf("fetch URL: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, StreamInfo{}, fmt.Errorf("fetch URL: %w", err)
+	}
+	req.Header.Set("User-Agent", m.userAgent)
+	req.Header.Set("Accept", m.acceptHeader())
+
+	resp, err := m.fetchClient.Do(req)
+	if err != nil {
+		return nil, StreamInfo{}, fmt.Errorf("fetch URL: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, StreamInfo{}, fmt.Errorf("fetch URL: %s", resp.Status)
+	}
+
+	// The response is bounded by both the response and the input size limits.
+	maxBytes := m.maxResponseBytes
+	if in := m.limits.maxInputBytes; in > 0 && (maxBytes <= 0 || in < maxBytes) {
+		maxBytes = in
+	}
+	if maxBytes > 0 && resp.ContentLength > maxBytes {
+		return nil, StreamInfo{}, &LimitExceededError{Limit: LimitInputBytes, Max: maxBytes, Actual: resp.ContentLength}
+	}
+	body := io.Reader(resp.Body)
+	if maxBytes > 0 {
+		body = io.LimitReader(resp.Body, maxBytes+1)
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, StreamInfo{}, fmt.Errorf("read response: %w", err)
+	}
+	if maxBytes > 0 && int64(len(data)) > maxBytes {
+		return nil, StreamInfo{}, &LimitExceededError{Limit: LimitInputBytes, Max: maxBytes, Actual: int64(len(data))}
+	}
+
+	final := resp.Request.URL
+	info := StreamInfo{
+		URL:      rawURL,
+		FinalURL: final.String(),
+		Header:   resp.Header,
+	}
+
+	// Content type and charset
+	if mediaType, params, err := mime.ParseMediaType(resp.Header.Get("Content-Type")); err == nil {
+		info.MIMEType = mediaType
+		info.Charset = params["charset"]
+	}
+
+	// Filename from Content-Disposition, else from the final URL path
+	if _, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition")); err == nil && params["filename"] != "" {
+		info.Filename = path.Base(params["filename"])
+	} else if ext := path.Ext(final.Path); ext != "" {
+		info.Filename = path.Base(final.Path)
+	}
+	info.Extension = strings.ToLower(path.Ext(info.Filename))
+
+	reader := bytes.NewReader(data)
+	if info.MIMEType == "" {
+		info.MIMEType = detectMIMEType(reader, info.Extension)
+		_, _ = reader.Seek(0, io.SeekStart)
+	}
+	return reader, info, nil
+}
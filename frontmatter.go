@@ -0,0 +1,73 @@
+// Copyright 2026 Conductor OSS
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+
+package markitdown
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// frontMatterOrder lists the well-known metadata keys in the order they are
+// written to front matter. Other keys follow in sorted order.
+var frontMatterOrder = []string{
+	MetadataTitle,
+	MetadataSubject,
+	MetadataAuthor,
+	MetadataLastModifiedBy,
+	MetadataCompany,
+	MetadataCreated,
+	MetadataModified,
+	MetadataKeywords,
+	MetadataCategory,
+	MetadataDescription,
+	MetadataLanguage,
+	MetadataPageCount,
+}
+
+var rePlainYAMLKey = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]*$`)
+
+// frontMatter renders metadata as a YAML front matter block, or returns ""
+// when there is none. Values are always double-quoted so that dates, numbers
+// and free text round-trip as strings.
+func frontMatter(metadata map[string]string) string {
+	if len(metadata) == 0 {
+		return ""
+	}
+	var keys []string
+	for _, k := range frontMatterOrder {
+		if _, ok := metadata[k]; ok {
+			keys = append(keys, k)
+		}
+	}
+	var rest []string
+	for k := range metadata {
+		if !slices.Contains(frontMatterOrder, k) {
+			rest = append(rest, k)
+		}
+	}
+	slices.Sort(rest)
+	keys = append(keys, rest...)
+
+	var b strings.Builder
+	b.WriteString("---\n")
+	for _, k := range keys {
+		key := k
+		if !rePlainYAMLKey.MatchString(key) {
+			key = strconv.Quote(key)
+		}
+		b.WriteString(key + ": " + strconv.Quote(metadata[k]) + "\n")
+	}
+	b.WriteString("---\n\n")
+	return b.String()
+}
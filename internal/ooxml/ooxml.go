@@ -132,3 +132,85 @@ func ResolveTarget(basePath, target string) string {
 	dir := path.Dir(basePath)
 	return path.Join(dir, target)
 }
+
+// CoreProperties holds the package properties stored in docProps/core.xml.
+type CoreProperties struct {
+	Title          string `xml:"title"`
+	Subject        string `xml:"subject"`
+	Creator        string `xml:"creator"`
+	Keywords       string `xml:"keywords"`
+	Description    string `xml:"description"`
+	LastModifiedBy string `xml:"lastModifiedBy"`
+	Revision       string `xml:"revision"`
+	Created        string `xml:"created"`
+	Modified       string `xml:"modified"`
+	Category       string `xml:"category"`
+	Language       string `xml:"language"`
+}
+
+// ParseCoreProperties parses docProps/core.xml from the ZIP. A package
+// without core properties yields an empty CoreProperties and no error.
+func ParseCoreProperties(zr *zip.Reader) (CoreProperties, error) {
+	var props CoreProperties
+	data, err := ReadFileFromZip(zr, "docProps/core.xml")
+	if err != nil {
+		return props, nil
+	}
+	if err := xml.Unmarshal(data, &props); err != nil {
+		return props, fmt.Errorf("decode core properties: %w", err)
+	}
+	return props, nil
+}
+
+// AppProperties holds the extended properties stored in docProps/app.xml.
+type AppProperties struct {
+	Application string `xml:"Application"`
+	Company     string `xml:"Company"`
+	Manager     string `xml:"Manager"`
+}
+
+// ParseAppProperties parses docProps/app.xml from the ZIP. A package without
+// extended properties yields an empty AppProperties and no error.
+func ParseAppProperties(zr *zip.Reader) (AppProperties, error) {
+	var props AppProperties
+	data, err := ReadFileFromZip(zr, "docProps/app.xml")
+	if err != nil {
+		return props, nil
+	}
+	if err := xml.Unmarshal(data, &props); err != nil {
+		return props, fmt.Errorf("decode app properties: %w", err)
+	}
+	return props, nil
+}
+
+// CustomProperty is a user-defined property from docProps/custom.xml. Value
+// is the text of the typed value (vt:lpwstr, vt:i4, vt:bool, vt:filetime, ...).
+type CustomProperty struct {
+	Name  string
+	Value string
+}
+
+// ParseCustomProperties parses docProps/custom.xml from the ZIP, in document
+// order. A package without custom properties yields nil and no error.
+func ParseCustomProperties(zr *zip.Reader) ([]CustomProperty, error) {
+	data, err := ReadFileFromZip(zr, "docProps/custom.xml")
+	if err != nil {
+		return nil, nil
+	}
+	var doc struct {
+		Properties []struct {
+			Name  string `xml:"name,attr"`
+			Value struct {
+				Text string `xml:",chardata"`
+			} `xml:",any"`
+		} `xml:"property"`
+	}
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("decode custom properties: %w", err)
+	}
+	props := make([]CustomProperty, 0, len(doc.Properties))
+	for _, p := range doc.Properties {
+		props = append(props, CustomProperty{Name: p.Name, Value: strings.TrimSpace(p.Value.Text)})
+	}
+	return props, nil
+}
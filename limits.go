@@ -0,0 +1,176 @@
+// Copyright 2026 Conductor OSS
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+
+package markitdown
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"io"
+)
+
+// Default resource limits. Input size and page count are unlimited by default.
+const (
+	DefaultMaxDecompressedBytes = 1 << 30
+	DefaultMaxArchiveEntries    = 10000
+	DefaultMaxNestingDepth      = 3
+)
+
+// limits holds the resource limits of a MarkItDown instance. A value of
+// zero or less disables the corresponding limit.
+type limits struct {
+	maxInputBytes        int64
+	maxDecompressedBytes int64
+	maxArchiveEntries    int
+	maxNestingDepth      int
+	maxPages             int
+}
+
+func defaultLimits() limits {
+	return limits{
+		maxDecompressedBytes: DefaultMaxDecompressedBytes,
+		maxArchiveEntries:    DefaultMaxArchiveEntries,
+		maxNestingDepth:      DefaultMaxNestingDepth,
+	}
+}
+
+// conversionState tracks resource usage across a top-level conversion,
+// including documents converted recursively from archives.
+type conversionState struct {
+	limits       limits
+	depth        int    // archive nesting depth
+	decompressed *int64 // bytes decompressed so far, shared by nested conversions
+
+	assets       map[[sha256.Size]byte]string // links of stored assets by content hash, shared by nested conversions
+	descriptions map[[sha256.Size]byte]string // generated image descriptions by content hash
+	warnings     *[]string                    // warnings raised outside a converter's result
+}
+
+type conversionStateKey struct{}
+
+// withConversionState returns ctx carrying the conversion state, creating
+// one from m's limits for a top-level conversion.
+func (m *MarkItDown) withConversionState(ctx context.Context) (context.Context, *conversionState) {
+	if st, ok := ctx.Value(conversionStateKey{}).(*conversionState); ok {
+		return ctx, st
+	}
+	st := newConversionState(m.limits)
+	return context.WithValue(ctx, conversionStateKey{}, st), st
+}
+
+// stateFrom returns the conversion state in ctx. Converters invoked directly,
+// outside MarkItDown, get an unlimited state.
+func stateFrom(ctx context.Context) *conversionState {
+	if st, ok := ctx.Value(conversionStateKey{}).(*conversionState); ok {
+		return st
+	}
+	return newConversionState(limits{})
+}
+
+func newConversionState(l limits) *conversionState {
+	return &conversionState{
+		limits:       l,
+		decompressed: new(int64),
+		assets:       make(map[[sha256.Size]byte]string),
+		descriptions: make(map[[sha256.Size]byte]string),
+		warnings:     new([]string),
+	}
+}
+
+// warn records a warning to be added to the result of the top-level
+// conversion.
+func (st *conversionState) warn(msg string) {
+	*st.warnings = append(*st.warnings, msg)
+}
+
+// checkInputSize enforces the input size limit on a seekable stream.
+func (st *conversionState) checkInputSize(r io.Seeker) error {
+	if st.limits.maxInputBytes <= 0 {
+		return nil
+	}
+	size, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	if size > st.limits.maxInputBytes {
+		return &LimitExceededError{Limit: LimitInputBytes, Max: st.limits.maxInputBytes, Actual: size}
+	}
+	return nil
+}
+
+// checkPages enforces the page limit.
+func (st *conversionState) checkPages(pages int) error {
+	if max := st.limits.maxPages; max > 0 && pages > max {
+		return &LimitExceededError{Limit: LimitPages, Max: int64(max), Actual: int64(pages)}
+	}
+	return nil
+}
+
+// checkArchive enforces the entry limit on an opened archive and charges its
+// total uncompressed size against the decompression budget. archive/zip
+// fails reads that go past an entry's declared size, so the declared sizes
+// bound what can actually be decompressed.
+func checkArchive(ctx context.Context, zr *zip.Reader) error {
+	st := stateFrom(ctx)
+	if max := st.limits.maxArchiveEntries; max > 0 && len(zr.File) > max {
+		return &LimitExceededError{Limit: LimitArchiveEntries, Max: int64(max), Actual: int64(len(zr.File))}
+	}
+
+	max := st.limits.maxDecompressedBytes
+	if max <= 0 {
+		return nil
+	}
+	// Summing stops past the budget: forged Zip64 sizes could otherwise
+	// wrap the total around to a small number.
+	var total uint64
+	for _, f := range zr.File {
+		if f.UncompressedSize64 > uint64(max)-total {
+			total = uint64(max) + 1
+			break
+		}
+		total += f.UncompressedSize64
+	}
+	if total > uint64(max) || *st.decompressed+int64(total) > max {
+		actual := *st.decompressed + int64(total)
+		return &LimitExceededError{Limit: LimitDecompressedBytes, Max: max, Actual: actual}
+	}
+	*st.decompressed += int64(total)
+	return nil
+}
+
+// chargeOutput charges n units of output that a document describes rather
+// than contains, such as the cells of a table grid, against the
+// decompression budget.
+func (st *conversionState) chargeOutput(n int64) error {
+	max := st.limits.maxDecompressedBytes
+	if max <= 0 {
+		return nil
+	}
+	if *st.decompressed+n > max {
+		return &LimitExceededError{Limit: LimitDecompressedBytes, Max: max, Actual: *st.decompressed + n}
+	}
+	*st.decompressed += n
+	return nil
+}
+
+// enterArchive returns ctx for converting the entries of a nested archive,
+// failing once the nesting depth limit is exceeded.
+func enterArchive(ctx context.Context) (context.Context, error) {
+	st := stateFrom(ctx)
+	depth := st.depth + 1
+	if max := st.limits.maxNestingDepth; max > 0 && depth > max {
+		return nil, &LimitExceededError{Limit: LimitNestingDepth, Max: int64(max), Actual: int64(depth)}
+	}
+	nested := *st
+	nested.depth = depth
+	return context.WithValue(ctx, conversionStateKey{}, &nested), nil
+}
@@ -12,14 +12,17 @@
 package markitdown
 
 import (
-	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
+	"runtime"
 	"sort"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/gabriel-vasile/mimetype"
 )
@@ -39,21 +42,70 @@ type registeredConverter struct {
 
 // MarkItDown is the main document-to-markdown conversion engine.
 type MarkItDown struct {
-	converters   []registeredConverter
-	keepDataURIs bool
-	styleMap     string
+	converters     []registeredConverter
+	keepDataURIs   bool
+	styleMap       string
+	trackedChanges TrackedChangesMode
+	headersFooters bool
+	frontMatter    bool
+	assetWriter    AssetWriter
+	imageDescriber ImageDescriber
+	ocr            OCREngine
+	pdfLayout      PDFLayout
+	pdfTOC         bool
+	pdfPages       string
+	pdfPageMarkers bool
+	password       string
+	limits         limits
+
+	pdfWorkers        int
+	pdfAcquireTimeout time.Duration
+	pdfium            *pdfiumPool // defaultPdfiumPool unless WithPDFWorkers is given
+	closed            atomic.Bool
+
+	httpClient       *http.Client
+	fetchClient      *http.Client // httpClient guarded by hostPolicy
+	userAgent        string
+	maxResponseBytes int64
+	hostPolicy       HostPolicy
 }
 
 // New creates a new MarkItDown instance with the given options.
 func New(opts ...Option) *MarkItDown {
-	m := &MarkItDown{}
+	m := &MarkItDown{
+		limits:            defaultLimits(),
+		userAgent:         DefaultUserAgent,
+		maxResponseBytes:  DefaultMaxResponseBytes,
+		pdfAcquireTimeout: DefaultPDFAcquireTimeout,
+	}
 	for _, opt := range opts {
 		opt(m)
 	}
+	m.pdfium = defaultPdfiumPool
+	if m.pdfWorkers > 0 {
+		// Instances of a MarkItDown that is never closed are released once
+		// it is garbage collected.
+		m.pdfium = &pdfiumPool{size: m.pdfWorkers}
+		runtime.AddCleanup(m, func(p *pdfiumPool) { p.close() }, m.pdfium)
+	}
+	m.fetchClient = m.newFetchClient()
 	m.enableBuiltins()
 	return m
 }
 
+// Close releases the PDF engine instances started for WithPDFWorkers, once
+// the conversions in progress are done with them; PDF conversions started
+// afterwards fail with ErrClosed. Without WithPDFWorkers, m shares the
+// package's instances and Close only stops its own PDF conversions. Closing
+// a MarkItDown that never converted a PDF is cheap.
+func (m *MarkItDown) Close() error {
+	m.closed.Store(true)
+	if m.pdfium == defaultPdfiumPool {
+		return nil
+	}
+	return m.pdfium.close()
+}
+
 // RegisterConverter adds a custom converter with the given priority.
 // Lower priority values are tried first.
 func (m *MarkItDown) RegisterConverter(name string, c DocumentConverter, priority float64) {
@@ -67,17 +119,41 @@ func (m *MarkItDown) RegisterConverter(name string, c DocumentConverter, priorit
 	})
 }
 
+// Formats lists the registered converters in priority order together with
+// the extensions and MIME types they advertise via FormatDescriber.
+func (m *MarkItDown) Formats() []FormatInfo {
+	formats := make([]FormatInfo, 0, len(m.converters))
+	for _, rc := range m.converters {
+		fi := FormatInfo{Converter: rc.name, Priority: rc.priority}
+		if fd, ok := rc.converter.(FormatDescriber); ok {
+			fi.Extensions, fi.MIMETypes = fd.Formats()
+		}
+		formats = append(formats, fi)
+	}
+	return formats
+}
+
 // Convert auto-detects the source type (file path or URL) and converts it.
 func (m *MarkItDown) Convert(source string) (*DocumentConverterResult, error) {
+	return m.ConvertContext(context.Background(), source)
+}
+
+// ConvertContext is like Convert but aborts when ctx is done.
+func (m *MarkItDown) ConvertContext(ctx context.Context, source string) (*DocumentConverterResult, error) {
 	// Check if it's a URL
 	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
-		return m.ConvertURL(source)
+		return m.ConvertURLContext(ctx, source)
 	}
-	return m.ConvertFile(source)
+	return m.ConvertFileContext(ctx, source)
 }
 
 // ConvertFile converts a local file to markdown.
 func (m *MarkItDown) ConvertFile(path string) (*DocumentConverterResult, error) {
+	return m.ConvertFileContext(context.Background(), path)
+}
+
+// ConvertFileContext is like ConvertFile but aborts when ctx is done.
+func (m *MarkItDown) ConvertFileContext(ctx context.Context, path string) (*DocumentConverterResult, error) {
 	f, err := os.Open(path)
 	if err != nil {
 		return nil, fmt.Errorf("open file: %w", err)
@@ -101,70 +177,77 @@ func (m *MarkItDown) ConvertFile(path string) (*DocumentConverterResult, error)
 		return nil, fmt.Errorf("seek: %w", err)
 	}
 
-	return m.ConvertReader(f, info)
+	return m.ConvertReaderContext(ctx, f, info)
 }
 
 // ConvertReader converts a stream to markdown using the provided StreamInfo.
 func (m *MarkItDown) ConvertReader(r io.ReadSeeker, info StreamInfo) (*DocumentConverterResult, error) {
-	return m.convert(r, info)
+	return m.ConvertReaderContext(context.Background(), r, info)
+}
+
+// ConvertReaderContext is like ConvertReader but aborts when ctx is done.
+func (m *MarkItDown) ConvertReaderContext(ctx context.Context, r io.ReadSeeker, info StreamInfo) (*DocumentConverterResult, error) {
+	return m.convert(ctx, r, info)
 }
 
 // ConvertURL fetches a URL and converts the response to markdown.
 func (m *MarkItDown) ConvertURL(url string) (*DocumentConverterResult, error) {
-	resp, err := http.Get(url) //nolint:gosec
-	if err != nil {
-		return nil, fmt.Errorf("fetch URL: %w", err)
-	}
-	defer resp.Body.Close()
+	return m.ConvertURLContext(context.Background(), url)
+}
 
-	data, err := io.ReadAll(resp.Body)
+// ConvertURLContext is like ConvertURL but aborts the fetch and the
+// conversion when ctx is done. The fetch follows the HTTP client, user
+// agent, response size and host policy options; StreamInfo.FinalURL and
+// StreamInfo.Header describe the response.
+func (m *MarkItDown) ConvertURLContext(ctx context.Context, url string) (*DocumentConverterResult, error) {
+	reader, info, err := m.fetch(ctx, url)
 	if err != nil {
-		return nil, fmt.Errorf("read response: %w", err)
+		return nil, err
 	}
+	return m.ConvertReaderContext(ctx, reader, info)
+}
 
-	reader := bytes.NewReader(data)
+type passwordKey struct{}
 
-	// Build StreamInfo from response
-	info := StreamInfo{
-		URL: url,
-	}
+// ContextWithPassword returns a copy of ctx carrying the password that opens
+// encrypted documents (PDF) converted with it, such as the files and URLs of
+// ConvertContext. It takes precedence over WithPassword; StreamInfo.Password
+// takes precedence over it.
+func ContextWithPassword(ctx context.Context, password string) context.Context {
+	return context.WithValue(ctx, passwordKey{}, password)
+}
 
-	// Extract content type
-	ct := resp.Header.Get("Content-Type")
-	if ct != "" {
-		parts := strings.Split(ct, ";")
-		info.MIMEType = strings.TrimSpace(parts[0])
-		for _, p := range parts[1:] {
-			p = strings.TrimSpace(p)
-			if strings.HasPrefix(p, "charset=") {
-				info.Charset = strings.Trim(strings.TrimPrefix(p, "charset="), `"'`)
-			}
-		}
+// documentPassword returns the password for a document: info.Password, or
+// else the one from ctx or from WithPassword.
+func (m *MarkItDown) documentPassword(ctx context.Context, info StreamInfo) string {
+	if info.Password != "" {
+		return info.Password
 	}
-
-	// Extract extension from URL path
-	urlPath := strings.Split(url, "?")[0]
-	info.Extension = strings.ToLower(filepath.Ext(urlPath))
-
-	// Extract filename from URL
-	if info.Extension != "" {
-		info.Filename = filepath.Base(urlPath)
+	if password, _ := ctx.Value(passwordKey{}).(string); password != "" {
+		return password
 	}
-
-	// Detect MIME if not provided
-	if info.MIMEType == "" {
-		info.MIMEType = detectMIMEType(reader, info.Extension)
-		_, _ = reader.Seek(0, io.SeekStart)
+	if m != nil {
+		return m.password
 	}
-
-	return m.ConvertReader(reader, info)
+	return ""
 }
 
 // convert is the internal dispatch method.
-func (m *MarkItDown) convert(r io.ReadSeeker, info StreamInfo) (*DocumentConverterResult, error) {
+func (m *MarkItDown) convert(ctx context.Context, r io.ReadSeeker, info StreamInfo) (*DocumentConverterResult, error) {
 	var failedAttempts []FailedConversionAttempt
 
+	// Archive entries are converted with the state of the enclosing conversion.
+	_, nested := ctx.Value(conversionStateKey{}).(*conversionState)
+	ctx, st := m.withConversionState(ctx)
+	if err := st.checkInputSize(r); err != nil {
+		return nil, err
+	}
+
 	for _, rc := range m.converters {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		if !rc.converter.Accepts(info) {
 			continue
 		}
@@ -174,8 +257,29 @@ func (m *MarkItDown) convert(r io.ReadSeeker, info StreamInfo) (*DocumentConvert
 			return nil, fmt.Errorf("seek: %w", err)
 		}
 
-		result, err := rc.converter.Convert(r, info)
+		// An attempt that fails gives back the decompression budget it
+		// used, as the next converter may read the same archive again.
+		charged := *st.decompressed
+
+		var result *DocumentConverterResult
+		var err error
+		if cc, ok := rc.converter.(ContextDocumentConverter); ok {
+			result, err = cc.ConvertContext(ctx, r, info)
+		} else {
+			result, err = rc.converter.Convert(r, info)
+		}
 		if err != nil {
+			// A cancelled conversion is not a converter failure; don't
+			// fall through to the next candidate.
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			// Likewise, a limit or a password applies to the input,
+			// whichever converter reads it.
+			if IsLimitExceeded(err) || IsPasswordRequired(err) {
+				return nil, err
+			}
+			*st.decompressed = charged
 			failedAttempts = append(failedAttempts, FailedConversionAttempt{
 				Converter: rc.name,
 				Err:       err,
@@ -185,6 +289,15 @@ func (m *MarkItDown) convert(r io.ReadSeeker, info StreamInfo) (*DocumentConvert
 
 		// Post-process / normalize output
 		result.Markdown = normalizeOutput(result.Markdown)
+		for i := range result.Segments {
+			result.Segments[i].Markdown = normalizeOutput(result.Segments[i].Markdown)
+		}
+		if !nested {
+			result.Warnings = append(result.Warnings, *st.warnings...)
+		}
+		if m.frontMatter && !nested {
+			result.Markdown = frontMatter(result.Metadata) + result.Markdown
+		}
 		return result, nil
 	}
 
@@ -205,11 +318,14 @@ func (m *MarkItDown) enableBuiltins() {
 	m.RegisterConverter("rss", NewRSSConverter(), PrioritySpecific)
 	m.RegisterConverter("ipynb", NewIpynbConverter(), PrioritySpecific)
 	m.RegisterConverter("docx", NewDocxConverter(m), PrioritySpecific)
-	m.RegisterConverter("xlsx", NewXlsxConverter(), PrioritySpecific)
+	m.RegisterConverter("xlsx", NewXlsxConverterFor(m), PrioritySpecific)
 	m.RegisterConverter("xls", NewXlsConverter(), PrioritySpecific)
 	m.RegisterConverter("pptx", NewPptxConverter(m), PrioritySpecific)
-	m.RegisterConverter("pdf", NewPdfConverter(), PrioritySpecific)
+	m.RegisterConverter("pdf", NewPdfConverterFor(m), PrioritySpecific)
 	m.RegisterConverter("epub", NewEpubConverter(m), PrioritySpecific)
+	if m.ocr != nil {
+		m.RegisterConverter("image", NewImageConverter(m), PrioritySpecific)
+	}
 
 	// Generic format converters (priority 10.0 - tried last as fallbacks)
 	m.RegisterConverter("html", NewHTMLConverter(m), PriorityGeneric)
@@ -252,6 +368,11 @@ func mimeFromExtension(ext string) string {
 		".epub":     "application/epub+zip",
 		".zip":      "application/zip",
 		".ipynb":    "application/x-ipynb+json",
+		".png":      "image/png",
+		".jpg":      "image/jpeg",
+		".jpeg":     "image/jpeg",
+		".tif":      "image/tiff",
+		".tiff":     "image/tiff",
 	}
 	if m, ok := extMap[ext]; ok {
 		return m
@@ -12,9 +12,32 @@
 package markitdown
 
 import (
+	"archive/zip"
+	"bytes"
+	"compress/flate"
+	"context"
+	"crypto/md5"
+	"crypto/rc4"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
 )
 
 // testVector defines a test case matching the Python test vectors.
@@ -199,6 +222,846 @@ func TestConvertReader(t *testing.T) {
 	})
 }
 
+// ctxRecordingConverter records whether ConvertContext was used.
+type ctxRecordingConverter struct {
+	gotCtx context.Context
+}
+
+func (c *ctxRecordingConverter) Accepts(info StreamInfo) bool { return info.Extension == ".ctxtest" }
+
+func (c *ctxRecordingConverter) Convert(reader io.ReadSeeker, info StreamInfo) (*DocumentConverterResult, error) {
+	return nil, errors.New("Convert should not be called")
+}
+
+func (c *ctxRecordingConverter) ConvertContext(ctx context.Context, reader io.ReadSeeker, info StreamInfo) (*DocumentConverterResult, error) {
+	c.gotCtx = ctx
+	return &DocumentConverterResult{Markdown: "ok"}, nil
+}
+
+// cancelingConverter converts .cancel files, cancelling the conversion
+// after the first one.
+type cancelingConverter struct {
+	cancel context.CancelFunc
+	calls  int
+}
+
+func (c *cancelingConverter) Accepts(info StreamInfo) bool { return info.Extension == ".cancel" }
+
+func (c *cancelingConverter) Convert(reader io.ReadSeeker, info StreamInfo) (*DocumentConverterResult, error) {
+	return c.ConvertContext(context.Background(), reader, info)
+}
+
+func (c *cancelingConverter) ConvertContext(ctx context.Context, reader io.ReadSeeker, info StreamInfo) (*DocumentConverterResult, error) {
+	c.calls++
+	c.cancel()
+	return &DocumentConverterResult{Markdown: "converted"}, nil
+}
+
+// cancelingOCR recognizes text, cancelling the conversion after the first
+// image.
+type cancelingOCR struct {
+	cancel context.CancelFunc
+	calls  int
+}
+
+func (o *cancelingOCR) Recognize(ctx context.Context, image []byte, mediaType string) (string, error) {
+	o.calls++
+	o.cancel()
+	return "recognized", nil
+}
+
+func TestConvertContext(t *testing.T) {
+	m := New()
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		for _, filename := range []string{"test.pdf", "test.docx", "test.epub", "test_files.zip"} {
+			_, err := m.ConvertFileContext(ctx, "testdata/"+filename)
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("ConvertFileContext(%s) error = %v, want context.Canceled", filename, err)
+			}
+		}
+	})
+
+	t.Run("cancelled during conversion", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		conv := &cancelingConverter{cancel: cancel}
+		m := New()
+		m.RegisterConverter("cancel", conv, PrioritySpecific)
+
+		archive := zipBytes(t, map[string][]byte{"a.cancel": []byte("a"), "b.cancel": []byte("b")})
+		result, err := m.ConvertReaderContext(ctx, bytes.NewReader(archive), StreamInfo{Extension: ".zip"})
+		if !errors.Is(err, context.Canceled) || result != nil {
+			t.Errorf("ZIP: result = %v, err = %v, want context.Canceled and no result", result, err)
+		}
+		if conv.calls != 1 {
+			t.Errorf("ZIP: converted %d entries after cancellation, want 1", conv.calls)
+		}
+
+		ctx, cancel = context.WithCancel(context.Background())
+		defer cancel()
+		ocr := &cancelingOCR{cancel: cancel}
+		result, err = New(WithOCR(ocr)).ConvertReaderContext(ctx, bytes.NewReader(blankPDF(3)), StreamInfo{Extension: ".pdf"})
+		if !errors.Is(err, context.Canceled) || result != nil {
+			t.Errorf("PDF: result = %v, err = %v, want context.Canceled and no result", result, err)
+		}
+		if ocr.calls != 1 {
+			t.Errorf("PDF: recognized %d pages after cancellation, want 1", ocr.calls)
+		}
+	})
+
+	t.Run("context converter", func(t *testing.T) {
+		type ctxKey struct{}
+		conv := &ctxRecordingConverter{}
+		m := New()
+		m.RegisterConverter("ctxtest", conv, PrioritySpecific)
+
+		ctx := context.WithValue(context.Background(), ctxKey{}, "v")
+		result, err := m.ConvertReaderContext(ctx, strings.NewReader("x"), StreamInfo{Extension: ".ctxtest"})
+		if err != nil {
+			t.Fatalf("ConvertReaderContext error: %v", err)
+		}
+		if result.Markdown != "ok" {
+			t.Errorf("Markdown = %q, want %q", result.Markdown, "ok")
+		}
+		if conv.gotCtx == nil || conv.gotCtx.Value(ctxKey{}) != "v" {
+			t.Errorf("converter did not receive the caller's context")
+		}
+	})
+}
+
+// buildDocx assembles a minimal DOCX package in memory. body is the inner XML
+// of w:body; extra holds additional parts keyed by ZIP path.
+func buildDocx(t *testing.T, body string, extra map[string]string) *bytes.Reader {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	parts := map[string]string{
+		"word/document.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+			`<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" ` +
+			`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><w:body>` +
+			body + `</w:body></w:document>`,
+	}
+	for name, content := range extra {
+		parts[name] = content
+	}
+	for name, content := range parts {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestDocxStyleMap(t *testing.T) {
+	styles := `<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+		`<w:style w:type="paragraph" w:styleId="TitleBig"><w:name w:val="Title Big"/></w:style>` +
+		`<w:style w:type="paragraph" w:styleId="CodeBlock"><w:name w:val="Code Block"/></w:style>` +
+		`<w:style w:type="paragraph" w:styleId="Callout"><w:name w:val="Callout"/></w:style>` +
+		`<w:style w:type="character" w:styleId="Emph"><w:name w:val="Emphasis"/></w:style>` +
+		`</w:styles>`
+	para := func(style, text string) string {
+		return `<w:p><w:pPr><w:pStyle w:val="` + style + `"/></w:pPr><w:r><w:t xml:space="preserve">` + text + `</w:t></w:r></w:p>`
+	}
+	body := para("TitleBig", "Quarterly Report") +
+		para("CodeBlock", "x := 1") +
+		para("CodeBlock", "y := 2") +
+		para("Callout", "Read this first.") +
+		`<w:p><w:r><w:t xml:space="preserve">plain </w:t></w:r>` +
+		`<w:r><w:rPr><w:rStyle w:val="Emph"/></w:rPr><w:t>stressed</w:t></w:r></w:p>`
+
+	m := New(WithStyleMap(`
+# corporate template
+p[style-name='Title Big'] => h1:fresh
+p[style-name='code block'] => pre
+p.Callout => blockquote > p
+r[style-name='Emphasis'] => em
+not a valid rule
+`))
+	result, err := m.ConvertReader(buildDocx(t, body, map[string]string{"word/styles.xml": styles}), StreamInfo{Extension: ".docx"})
+	if err != nil {
+		t.Fatalf("ConvertReader error: %v", err)
+	}
+
+	for _, want := range []string{
+		"# Quarterly Report",
+		"```\nx := 1\ny := 2\n```",
+		"> Read this first.",
+		"plain *stressed*",
+	} {
+		if !strings.Contains(result.Markdown, want) {
+			t.Errorf("expected output to contain %q\nGot:\n%s", want, result.Markdown)
+		}
+	}
+}
+
+func TestDocxNotes(t *testing.T) {
+	const ns = `xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"`
+	footnotes := `<w:footnotes ` + ns + `>` +
+		`<w:footnote w:type="separator" w:id="-1"><w:p><w:r><w:separator/></w:r></w:p></w:footnote>` +
+		`<w:footnote w:id="1"><w:p><w:r><w:footnoteRef/></w:r><w:r><w:t xml:space="preserve"> See </w:t></w:r>` +
+		`<w:hyperlink r:id="rId1"><w:r><w:t>the statute</w:t></w:r></w:hyperlink>` +
+		`<w:r><w:rPr><w:i/></w:rPr><w:t>, s. 12</w:t></w:r></w:p>` +
+		`<w:p><w:r><w:t>Second paragraph.</w:t></w:r></w:p></w:footnote>` +
+		`</w:footnotes>`
+	endnotes := `<w:endnotes ` + ns + `>` +
+		`<w:endnote w:id="2"><w:p><w:r><w:rPr><w:b/></w:rPr><w:t>Closing note.</w:t></w:r></w:p></w:endnote>` +
+		`</w:endnotes>`
+	rels := `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink" ` +
+		`Target="https://example.com/statute" TargetMode="External"/></Relationships>`
+	body := `<w:p><w:r><w:t>Claim</w:t></w:r><w:r><w:footnoteReference w:id="1"/></w:r>` +
+		`<w:r><w:t xml:space="preserve"> and conclusion</w:t></w:r><w:r><w:endnoteReference w:id="2"/></w:r>` +
+		`<w:r><w:t xml:space="preserve"> again</w:t></w:r><w:r><w:footnoteReference w:id="1"/></w:r></w:p>`
+
+	doc := buildDocx(t, body, map[string]string{
+		"word/footnotes.xml":            footnotes,
+		"word/endnotes.xml":             endnotes,
+		"word/_rels/footnotes.xml.rels": rels,
+	})
+	result, err := New().ConvertReader(doc, StreamInfo{Extension: ".docx"})
+	if err != nil {
+		t.Fatalf("ConvertReader error: %v", err)
+	}
+
+	for _, want := range []string{
+		"Claim[^1] and conclusion[^2] again[^1]",
+		"[^1]: See [the statute](https://example.com/statute)*, s. 12*\n\n    Second paragraph.",
+		"[^2]: **Closing note.**",
+	} {
+		if !strings.Contains(result.Markdown, want) {
+			t.Errorf("expected output to contain %q\nGot:\n%s", want, result.Markdown)
+		}
+	}
+	if strings.Count(result.Markdown, "[^1]:") != 1 {
+		t.Errorf("footnote 1 should be defined once:\n%s", result.Markdown)
+	}
+}
+
+func TestDocxTrackedChanges(t *testing.T) {
+	body := `<w:p><w:r><w:t xml:space="preserve">The fee is </w:t></w:r>` +
+		`<w:del w:id="1" w:author="Alice" w:date="2024-05-01T10:00:00Z"><w:r><w:delText>$100</w:delText></w:r></w:del>` +
+		`<w:ins w:id="2" w:author="Bob" w:date="2024-05-02T11:00:00Z"><w:r><w:t>$200</w:t></w:r></w:ins>` +
+		`<w:r><w:t xml:space="preserve"> per month.</w:t></w:r></w:p>` +
+		`<w:p><w:pPr><w:rPr><w:ins w:id="3" w:author="Bob"/></w:rPr></w:pPr>` +
+		`<w:moveFrom w:id="4" w:author="Carol"><w:r><w:t>Moved clause.</w:t></w:r></w:moveFrom></w:p>`
+
+	tests := []struct {
+		mode    TrackedChangesMode
+		want    []string
+		notWant []string
+	}{
+		{TrackedChangesAccept, []string{"The fee is $200 per month."}, []string{"$100", "Moved clause."}},
+		{TrackedChangesReject, []string{"The fee is $100 per month.", "Moved clause."}, []string{"$200"}},
+		{TrackedChangesInline, []string{
+			"The fee is {--$100--}{>>Alice, 2024-05-01T10:00:00Z<<}{++$200++}{>>Bob, 2024-05-02T11:00:00Z<<} per month.",
+			"{--Moved clause.--}{>>Carol<<}",
+		}, []string{"{++++}"}},
+	}
+	for _, tt := range tests {
+		result, err := New(WithTrackedChanges(tt.mode)).ConvertReader(buildDocx(t, body, nil), StreamInfo{Extension: ".docx"})
+		if err != nil {
+			t.Fatalf("mode %d: ConvertReader error: %v", tt.mode, err)
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(result.Markdown, want) {
+				t.Errorf("mode %d: expected output to contain %q\nGot:\n%s", tt.mode, want, result.Markdown)
+			}
+		}
+		for _, notWant := range tt.notWant {
+			if strings.Contains(result.Markdown, notWant) {
+				t.Errorf("mode %d: output should not contain %q\nGot:\n%s", tt.mode, notWant, result.Markdown)
+			}
+		}
+	}
+}
+
+func TestDocProperties(t *testing.T) {
+	extra := map[string]string{
+		"docProps/core.xml": `<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" ` +
+			`xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:dcterms="http://purl.org/dc/terms/">` +
+			`<dc:title>Q3 Report</dc:title><dc:creator>Alice</dc:creator><cp:lastModifiedBy>Bob</cp:lastModifiedBy>` +
+			`<cp:keywords>finance, quarterly</cp:keywords><dcterms:created>2024-05-01T10:00:00Z</dcterms:created>` +
+			`</cp:coreProperties>`,
+		"docProps/app.xml": `<Properties xmlns="http://schemas.openxmlformats.org/officeDocument/2006/extended-properties">` +
+			`<Application>Microsoft Office Word</Application><Company>ACME "Corp"</Company></Properties>`,
+		"docProps/custom.xml": `<Properties xmlns="http://schemas.openxmlformats.org/officeDocument/2006/custom-properties" ` +
+			`xmlns:vt="http://schemas.openxmlformats.org/officeDocument/2006/docPropsVTypes">` +
+			`<property fmtid="{D5CDD505-2E9C-101B-9397-08002B2CF9AE}" pid="2" name="Classification"><vt:lpwstr>Internal</vt:lpwstr></property>` +
+			`<property fmtid="{D5CDD505-2E9C-101B-9397-08002B2CF9AE}" pid="3" name="Doc ID"><vt:i4>4711</vt:i4></property>` +
+			`</Properties>`,
+	}
+	body := `<w:p><w:r><w:t>Body text</w:t></w:r></w:p>`
+
+	result, err := New().ConvertReader(buildDocx(t, body, extra), StreamInfo{Extension: ".docx"})
+	if err != nil {
+		t.Fatalf("ConvertReader error: %v", err)
+	}
+	if result.Title != "Q3 Report" {
+		t.Errorf("Title = %q, want %q", result.Title, "Q3 Report")
+	}
+	want := map[string]string{
+		MetadataTitle:                           "Q3 Report",
+		MetadataAuthor:                          "Alice",
+		MetadataLastModifiedBy:                  "Bob",
+		MetadataKeywords:                        "finance, quarterly",
+		MetadataCreated:                         "2024-05-01T10:00:00Z",
+		MetadataCompany:                         `ACME "Corp"`,
+		MetadataCustomPrefix + "Classification": "Internal",
+		MetadataCustomPrefix + "Doc ID":         "4711",
+	}
+	for k, v := range want {
+		if got := result.Metadata[k]; got != v {
+			t.Errorf("Metadata[%q] = %q, want %q", k, got, v)
+		}
+	}
+	if strings.HasPrefix(result.Markdown, "---") {
+		t.Errorf("front matter emitted without WithFrontMatter:\n%s", result.Markdown)
+	}
+
+	result, err = New(WithFrontMatter(true)).ConvertReader(buildDocx(t, body, extra), StreamInfo{Extension: ".docx"})
+	if err != nil {
+		t.Fatalf("ConvertReader error: %v", err)
+	}
+	wantMd := "---\n" +
+		"title: \"Q3 Report\"\n" +
+		"author: \"Alice\"\n" +
+		"last_modified_by: \"Bob\"\n" +
+		"company: \"ACME \\\"Corp\\\"\"\n" +
+		"created: \"2024-05-01T10:00:00Z\"\n" +
+		"keywords: \"finance, quarterly\"\n" +
+		"custom.Classification: \"Internal\"\n" +
+		"\"custom.Doc ID\": \"4711\"\n" +
+		"---\n\n" +
+		"Body text"
+	if result.Markdown != wantMd {
+		t.Errorf("Markdown =\n%s\nwant\n%s", result.Markdown, wantMd)
+	}
+}
+
+func TestDocxLists(t *testing.T) {
+	numbering := `<w:numbering xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+		`<w:abstractNum w:abstractNumId="0">` +
+		`<w:lvl w:ilvl="0"><w:start w:val="1"/><w:numFmt w:val="decimal"/></w:lvl>` +
+		`<w:lvl w:ilvl="1"><w:start w:val="1"/><w:numFmt w:val="lowerLetter"/></w:lvl></w:abstractNum>` +
+		`<w:abstractNum w:abstractNumId="1"><w:lvl w:ilvl="0"><w:numFmt w:val="bullet"/></w:lvl></w:abstractNum>` +
+		`<w:num w:numId="1"><w:abstractNumId w:val="0"/></w:num>` +
+		`<w:num w:numId="2"><w:abstractNumId w:val="0"/>` +
+		`<w:lvlOverride w:ilvl="0"><w:startOverride w:val="5"/></w:lvlOverride></w:num>` +
+		`<w:num w:numId="3"><w:abstractNumId w:val="1"/></w:num>` +
+		`<w:num w:numId="4"><w:abstractNumId w:val="0"/></w:num></w:numbering>`
+	item := func(numID, ilvl, text string) string {
+		return `<w:p><w:pPr><w:numPr><w:ilvl w:val="` + ilvl + `"/><w:numId w:val="` + numID + `"/></w:numPr></w:pPr>` +
+			`<w:r><w:t>` + text + `</w:t></w:r></w:p>`
+	}
+	para := func(text string) string { return `<w:p><w:r><w:t>` + text + `</w:t></w:r></w:p>` }
+	body := para("Requirements") +
+		item("1", "0", "Login") + item("1", "1", "SSO") + item("1", "1", "MFA") +
+		`<w:p/>` + item("1", "0", "Logout") +
+		para("Note") +
+		item("1", "0", "Audit") + item("3", "0", "Bullet") +
+		para("Appendix") +
+		item("2", "0", "Restarted") + item("2", "0", "Next") +
+		para("Later") +
+		item("4", "0", "Shared")
+
+	result, err := New().ConvertReader(buildDocx(t, body, map[string]string{"word/numbering.xml": numbering}), StreamInfo{Extension: ".docx"})
+	if err != nil {
+		t.Fatalf("ConvertReader error: %v", err)
+	}
+	for _, want := range []string{
+		"Requirements\n\n1. Login\n\n   1. SSO\n   2. MFA\n2. Logout\n\nNote",
+		"Note\n\n3. Audit\n",
+		"- Bullet\n\nAppendix",
+		"Appendix\n\n5. Restarted\n6. Next",
+		// Instance 4 continues the numbering of instance 1.
+		"Later\n\n4. Shared",
+	} {
+		if !strings.Contains(result.Markdown, want) {
+			t.Errorf("expected %q in:\n%s", want, result.Markdown)
+		}
+	}
+}
+
+func TestDocxTables(t *testing.T) {
+	cell := func(props, text string) string {
+		return `<w:tc><w:tcPr>` + props + `</w:tcPr><w:p><w:r><w:t>` + text + `</w:t></w:r></w:p></w:tc>`
+	}
+	row := func(props string, cells ...string) string {
+		return `<w:tr><w:trPr>` + props + `</w:trPr>` + strings.Join(cells, "") + `</w:tr>`
+	}
+	nested := `<w:tbl>` + row("", cell("", "Q1"), cell("", "Q2")) + row("", cell("", "10"), cell("", "20")) + `</w:tbl>`
+	body := `<w:p><w:r><w:t>Report</w:t></w:r></w:p><w:tbl>` +
+		row("", cell(`<w:gridSpan w:val="3"/>`, "Quarterly results")) +
+		row(`<w:tblHeader/>`, cell("", "Region"), cell("", "Revenue"), cell("", "Cost")) +
+		row("", cell(`<w:vMerge w:val="restart"/>`, "North"), cell("", "100"), cell("", "60")) +
+		row("", cell(`<w:vMerge/>`, ""), cell(`<w:gridSpan w:val="2"/>`, "n/a")) +
+		row("", cell("", "South"), `<w:tc><w:p><w:r><w:t>Split</w:t></w:r></w:p>`+nested+`</w:tc>`, cell("", "40")) +
+		`</w:tbl>`
+
+	result, err := New().ConvertReader(buildDocx(t, body, nil), StreamInfo{Extension: ".docx"})
+	if err != nil {
+		t.Fatalf("ConvertReader error: %v", err)
+	}
+	compact := regexp.MustCompile(` +`).ReplaceAllString(result.Markdown, " ")
+	for _, want := range []string{
+		"| Quarterly results | | |",
+		"| Region | Revenue | Cost |",
+		"| North | 100 | 60 |",
+		"| | n/a | |",
+		"| South | Split Q1 / Q2; 10 / 20 | 40 |",
+	} {
+		if !strings.Contains(compact, want) {
+			t.Errorf("expected %q in table:\n%s", want, result.Markdown)
+		}
+	}
+	for _, line := range strings.Split(result.Markdown, "\n") {
+		if strings.HasPrefix(line, "|") && strings.Count(line, "|")-strings.Count(line, "\\|") != 4 {
+			t.Errorf("row %q does not have 3 columns", line)
+		}
+	}
+
+	// Leading tblHeader rows form the table header.
+	body = `<w:tbl>` +
+		row(`<w:tblHeader/>`, cell("", "Item"), cell("", "Qty")) +
+		row("", cell("", "Apples"), cell("", "3")) +
+		`</w:tbl>`
+	result, err = New().ConvertReader(buildDocx(t, body, nil), StreamInfo{Extension: ".docx"})
+	if err != nil {
+		t.Fatalf("ConvertReader error: %v", err)
+	}
+	if !strings.HasPrefix(result.Markdown, "| Item   | Qty |\n|--------|-----|\n| Apples | 3   |") {
+		t.Errorf("unexpected table:\n%s", result.Markdown)
+	}
+
+	// Oversized spans are clamped to the table grid, or to Word's 63
+	// columns without one.
+	for _, tt := range []struct {
+		grid    string
+		columns int
+	}{
+		{`<w:tblGrid><w:gridCol/><w:gridCol/></w:tblGrid>`, 2},
+		{"", docxMaxColumns},
+	} {
+		body = `<w:tbl>` + tt.grid +
+			row(`<w:gridBefore w:val="2000000000"/>`, cell(`<w:gridSpan w:val="2000000000"/>`, "Wide")) +
+			row(`<w:gridAfter w:val="3000000"/>`, cell("", "A"), cell(`<w:gridSpan w:val="3000000"/>`, "B")) +
+			`</w:tbl>`
+		result, err = New().ConvertReader(buildDocx(t, body, nil), StreamInfo{Extension: ".docx"})
+		if err != nil {
+			t.Fatalf("ConvertReader error: %v", err)
+		}
+		header, _, _ := strings.Cut(result.Markdown, "\n")
+		if n := strings.Count(header, "|") - 1; n != tt.columns {
+			t.Errorf("grid %q: table has %d columns, want %d:\n%.300s", tt.grid, n, tt.columns, result.Markdown)
+		}
+		if !strings.Contains(result.Markdown, "Wide") || !strings.Contains(result.Markdown, "B") {
+			t.Errorf("grid %q: cell content missing:\n%.300s", tt.grid, result.Markdown)
+		}
+	}
+}
+
+func TestDocxHeadersFootersAndTextBoxes(t *testing.T) {
+	const ns = `xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"`
+	part := func(root, text string) string {
+		return `<w:` + root + ` ` + ns + `><w:p><w:r><w:t>` + text + `</w:t></w:r></w:p></w:` + root + `>`
+	}
+	rels := `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rH1" Type="header" Target="header1.xml"/>` +
+		`<Relationship Id="rH2" Type="header" Target="header2.xml"/>` +
+		`<Relationship Id="rF1" Type="footer" Target="footer1.xml"/></Relationships>`
+	textBox := `<w:r><mc:AlternateContent xmlns:mc="http://schemas.openxmlformats.org/markup-compatibility/2006">` +
+		`<mc:Choice Requires="wps"><w:drawing><wps:wsp xmlns:wps="http://schemas.microsoft.com/office/word/2010/wordprocessingShape">` +
+		`<wps:txbx><w:txbxContent><w:p><w:r><w:t>Document ID: 4711</w:t></w:r></w:p></w:txbxContent></wps:txbx>` +
+		`</wps:wsp></w:drawing></mc:Choice><mc:Fallback><w:pict><v:shape xmlns:v="urn:schemas-microsoft-com:vml"><v:textbox>` +
+		`<w:txbxContent><w:p><w:r><w:t>Document ID: 4711</w:t></w:r></w:p></w:txbxContent></v:textbox></v:shape></w:pict>` +
+		`</mc:Fallback></mc:AlternateContent></w:r>`
+	body := `<w:p><w:r><w:t>Anchor paragraph</w:t></w:r>` + textBox + `</w:p>` +
+		`<w:p><w:pPr><w:sectPr><w:headerReference w:type="default" r:id="rH1"/>` +
+		`<w:footerReference w:type="default" r:id="rF1"/></w:sectPr></w:pPr><w:r><w:t>End of section one</w:t></w:r></w:p>` +
+		`<w:p><w:r><w:t>Section two</w:t></w:r></w:p>` +
+		`<w:sectPr><w:headerReference w:type="default" r:id="rH2"/><w:headerReference w:type="first" r:id="rH1"/>` +
+		`<w:footerReference w:type="default" r:id="rF1"/></w:sectPr>`
+	extra := map[string]string{
+		"word/_rels/document.xml.rels": rels,
+		"word/header1.xml":             part("hdr", "CONFIDENTIAL"),
+		"word/header2.xml":             part("hdr", "Appendix header"),
+		"word/footer1.xml":             part("ftr", "Page footer"),
+	}
+
+	result, err := New().ConvertReader(buildDocx(t, body, extra), StreamInfo{Extension: ".docx"})
+	if err != nil {
+		t.Fatalf("ConvertReader error: %v", err)
+	}
+	if !strings.Contains(result.Markdown, "Anchor paragraph\n\nDocument ID: 4711\n\nEnd of section one") {
+		t.Errorf("text box missing or out of order:\n%s", result.Markdown)
+	}
+	if n := strings.Count(result.Markdown, "Document ID"); n != 1 {
+		t.Errorf("text box appears %d times, want 1:\n%s", n, result.Markdown)
+	}
+	if strings.Contains(result.Markdown, "CONFIDENTIAL") {
+		t.Errorf("headers included without WithHeadersFooters:\n%s", result.Markdown)
+	}
+
+	result, err = New(WithHeadersFooters(true)).ConvertReader(buildDocx(t, body, extra), StreamInfo{Extension: ".docx"})
+	if err != nil {
+		t.Fatalf("ConvertReader error: %v", err)
+	}
+	want := []string{"CONFIDENTIAL", "Anchor paragraph", "End of section one", "Page footer", "Appendix header", "Section two"}
+	pos := 0
+	for _, w := range want {
+		i := strings.Index(result.Markdown[pos:], w)
+		if i < 0 {
+			t.Fatalf("expected %q after offset %d:\n%s", w, pos, result.Markdown)
+		}
+		pos += i + len(w)
+	}
+	for _, w := range []string{"CONFIDENTIAL", "Page footer"} {
+		if n := strings.Count(result.Markdown, w); n != 1 {
+			t.Errorf("%q appears %d times, want 1:\n%s", w, n, result.Markdown)
+		}
+	}
+}
+
+func TestStructuredResult(t *testing.T) {
+	m := New()
+
+	tests := []struct {
+		filename     string
+		kind         SegmentKind
+		segments     int
+		metadata     map[string]string
+		warningMatch string
+	}{
+		{"test.pdf", SegmentPage, 1, map[string]string{MetadataPageCount: "1"}, ""},
+		{"test.pptx", SegmentSlide, 6, map[string]string{MetadataAuthor: "Adam Fourney", MetadataPageCount: "6"}, ""},
+		{"test.xlsx", SegmentSheet, 2, map[string]string{MetadataAuthor: "Adam Fourney"}, ""},
+		{"test.docx", "", 0, map[string]string{MetadataAuthor: "Adam Fourney", MetadataCreated: "2024-03-15T05:45:00Z"}, ""},
+		{"test.epub", SegmentChapter, 3, map[string]string{MetadataAuthor: "Test Author", MetadataLanguage: "en"}, ""},
+		{"test_rss.xml", "", 0, map[string]string{MetadataLanguage: "en-US"}, ""},
+		{"test_files.zip", SegmentEntry, 6, nil, "ZIP entry test.jpg skipped"},
+	}
+	// Units that produce no segment, like the skipped test.jpg, still count.
+	numbers := map[string][]int{"test_files.zip": {1, 3, 4, 5, 6, 7}}
+
+	for _, tt := range tests {
+		t.Run(tt.filename, func(t *testing.T) {
+			result, err := m.ConvertFile("testdata/" + tt.filename)
+			if err != nil {
+				t.Fatalf("ConvertFile error: %v", err)
+			}
+			if len(result.Segments) != tt.segments {
+				t.Fatalf("got %d segments, want %d", len(result.Segments), tt.segments)
+			}
+			for i, seg := range result.Segments {
+				if seg.Kind != tt.kind {
+					t.Errorf("segment %d kind = %q, want %q", i, seg.Kind, tt.kind)
+				}
+				if seg.Markdown == "" || !strings.Contains(result.Markdown, seg.Markdown[:min(len(seg.Markdown), 40)]) {
+					t.Errorf("segment %d markdown not found in document markdown", i)
+				}
+				if want, ok := numbers[tt.filename]; ok && seg.Number != want[i] {
+					t.Errorf("segment %d number = %d, want %d", i, seg.Number, want[i])
+				}
+			}
+			for k, want := range tt.metadata {
+				if got := result.Metadata[k]; got != want {
+					t.Errorf("Metadata[%q] = %q, want %q", k, got, want)
+				}
+			}
+			if tt.warningMatch != "" {
+				found := false
+				for _, w := range result.Warnings {
+					found = found || strings.Contains(w, tt.warningMatch)
+				}
+				if !found {
+					t.Errorf("expected a warning containing %q, got %q", tt.warningMatch, result.Warnings)
+				}
+			}
+		})
+	}
+}
+
+// zipBytes builds a ZIP archive from the given entries.
+func zipBytes(t *testing.T, entries map[string][]byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range entries {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestLimits(t *testing.T) {
+	nested := []byte("innermost")
+	for i := 0; i < 4; i++ {
+		nested = zipBytes(t, map[string][]byte{"level.zip": nested})
+	}
+	bomb := zipBytes(t, map[string][]byte{"zeros.txt": make([]byte, 1<<20)})
+	many := map[string][]byte{}
+	for i := 0; i < 20; i++ {
+		many[fmt.Sprintf("f%d.txt", i)] = []byte("x")
+	}
+
+	tests := []struct {
+		name  string
+		opts  []Option
+		data  []byte
+		ext   string
+		limit Limit
+	}{
+		{"nesting", nil, nested, ".zip", LimitNestingDepth},
+		{"decompressed", []Option{WithMaxDecompressedBytes(64 << 10)}, bomb, ".zip", LimitDecompressedBytes},
+		{"entries", []Option{WithMaxArchiveEntries(10)}, zipBytes(t, many), ".zip", LimitArchiveEntries},
+		{"input", []Option{WithMaxInputBytes(4)}, []byte("hello world"), ".txt", LimitInputBytes},
+		{"docx", []Option{WithMaxDecompressedBytes(16)}, readAll(t, buildDocx(t, "<w:p/>", nil)), ".docx", LimitDecompressedBytes},
+		{"forged zip64 sizes", []Option{WithMaxDecompressedBytes(1 << 20)}, forgedZip64(t, 1<<64-1, 2), ".zip", LimitDecompressedBytes},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.opts...).ConvertReader(bytes.NewReader(tt.data), StreamInfo{Extension: tt.ext})
+			var limitErr *LimitExceededError
+			if !errors.As(err, &limitErr) {
+				t.Fatalf("got error %v, want LimitExceededError", err)
+			}
+			if limitErr.Limit != tt.limit {
+				t.Errorf("limit = %q, want %q", limitErr.Limit, tt.limit)
+			}
+		})
+	}
+
+	// Within limits, the same inputs convert.
+	if _, err := New(WithMaxNestingDepth(5)).ConvertReader(bytes.NewReader(nested), StreamInfo{Extension: ".zip"}); err != nil {
+		t.Errorf("nested ZIP within depth limit: %v", err)
+	}
+	if _, err := New(WithMaxPages(1)).ConvertFile("testdata/test.pdf"); err != nil {
+		t.Errorf("PDF within page limit: %v", err)
+	}
+}
+
+// forgedZip64 returns a ZIP archive whose entries hold 2 MiB of zeros each
+// but declare the given uncompressed sizes.
+func forgedZip64(t *testing.T, sizes ...uint64) []byte {
+	t.Helper()
+	var deflated bytes.Buffer
+	fw, _ := flate.NewWriter(&deflated, flate.BestCompression)
+	zeros := make([]byte, 2<<20)
+	fw.Write(zeros)
+	fw.Close()
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for i, size := range sizes {
+		w, err := zw.CreateRaw(&zip.FileHeader{
+			Name:               fmt.Sprintf("f%d.txt", i),
+			Method:             zip.Deflate,
+			CRC32:              crc32.ChecksumIEEE(zeros),
+			CompressedSize64:   uint64(deflated.Len()),
+			UncompressedSize64: size,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write(deflated.Bytes())
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// failingArchiveConverter opens ZIP archives, charging them against the
+// decompression budget, and then fails.
+type failingArchiveConverter struct{}
+
+func (failingArchiveConverter) Accepts(info StreamInfo) bool { return info.Extension == ".zip" }
+
+func (c failingArchiveConverter) Convert(reader io.ReadSeeker, info StreamInfo) (*DocumentConverterResult, error) {
+	return c.ConvertContext(context.Background(), reader, info)
+}
+
+func (failingArchiveConverter) ConvertContext(ctx context.Context, reader io.ReadSeeker, info StreamInfo) (*DocumentConverterResult, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	if err := checkArchive(ctx, zr); err != nil {
+		return nil, err
+	}
+	return nil, errors.New("not my kind of archive")
+}
+
+func TestLimitsFallThrough(t *testing.T) {
+	// Both converters read the 600-byte archive; only the one that succeeds
+	// counts against the 1000-byte budget.
+	archive := zipBytes(t, map[string][]byte{"a.txt": bytes.Repeat([]byte("a"), 600)})
+	m := New(WithMaxDecompressedBytes(1000))
+	m.RegisterConverter("failing", failingArchiveConverter{}, PrioritySpecific)
+
+	result, err := m.ConvertReader(bytes.NewReader(archive), StreamInfo{Extension: ".zip"})
+	if err != nil {
+		t.Fatalf("ConvertReader error: %v", err)
+	}
+	if !strings.Contains(result.Markdown, "aaaa") {
+		t.Errorf("markdown = %q, want the entry's text", result.Markdown)
+	}
+}
+
+func readAll(t *testing.T, r io.Reader) []byte {
+	t.Helper()
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestConvertURL(t *testing.T) {
+	var gotUA, gotAccept string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/old":
+			http.Redirect(w, r, "/docs/report.csv", http.StatusFound)
+		case "/docs/report.csv":
+			gotUA, gotAccept = r.UserAgent(), r.Header.Get("Accept")
+			w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+			w.Header().Set("X-Request-Id", "abc")
+			io.WriteString(w, "a,b\n1,2\n")
+		case "/big":
+			w.Write(make([]byte, 1024))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+	host := strings.TrimPrefix(ts.URL, "http://")
+
+	var seen StreamInfo
+	m := New(WithUserAgent("test-agent"), WithHostPolicy(HostPolicy{AllowPrivate: true}))
+	m.RegisterConverter("spy", &spyConverter{info: &seen}, PrioritySpecific-1)
+	result, err := m.ConvertURL(ts.URL + "/old")
+	if err != nil {
+		t.Fatalf("ConvertURL error: %v", err)
+	}
+	if !strings.Contains(result.Markdown, "| a | b |") {
+		t.Errorf("unexpected markdown: %q", result.Markdown)
+	}
+	if seen.FinalURL != ts.URL+"/docs/report.csv" || seen.URL != ts.URL+"/old" {
+		t.Errorf("URL = %q, FinalURL = %q", seen.URL, seen.FinalURL)
+	}
+	if seen.Header.Get("X-Request-Id") != "abc" || seen.Charset != "utf-8" || seen.Extension != ".csv" {
+		t.Errorf("unexpected stream info: %+v", seen)
+	}
+	if gotUA != "test-agent" {
+		t.Errorf("User-Agent = %q", gotUA)
+	}
+	if !strings.Contains(gotAccept, "text/csv") || !strings.Contains(gotAccept, "application/pdf") {
+		t.Errorf("Accept = %q, want registered MIME types", gotAccept)
+	}
+
+	tests := []struct {
+		name string
+		m    *MarkItDown
+		path string
+		want func(error) bool
+	}{
+		{"private blocked by default", New(), "/docs/report.csv", func(err error) bool { return errors.Is(err, ErrHostNotAllowed) }},
+		{"deny list", New(WithHostPolicy(HostPolicy{AllowPrivate: true, Deny: []string{"127.0.0.1"}})), "/docs/report.csv", func(err error) bool { return errors.Is(err, ErrHostNotAllowed) }},
+		{"allow list", New(WithHostPolicy(HostPolicy{AllowPrivate: true, Allow: []string{"*.example.com"}})), "/docs/report.csv", func(err error) bool { return errors.Is(err, ErrHostNotAllowed) }},
+		{"response size", New(WithHostPolicy(HostPolicy{AllowPrivate: true}), WithMaxResponseBytes(100)), "/big", IsLimitExceeded},
+		{"status", New(WithHostPolicy(HostPolicy{AllowPrivate: true})), "/missing", func(err error) bool { return err != nil && strings.Contains(err.Error(), "404") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.m.ConvertURL("http://" + host + tt.path)
+			if !tt.want(err) {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+
+	// Host names are checked against the address actually dialed.
+	_, port, _ := strings.Cut(host, ":")
+	if _, err := New().ConvertURL("http://localhost:" + port + "/docs/report.csv"); !errors.Is(err, ErrHostNotAllowed) {
+		t.Errorf("localhost: got %v, want ErrHostNotAllowed", err)
+	}
+}
+
+func TestHostPolicy(t *testing.T) {
+	var p HostPolicy
+	for _, host := range []string{"127.0.0.1", "10.1.2.3", "169.254.169.254", "::1", "fd00::1", "100.64.0.1", "0.0.0.0", "::ffff:192.168.1.1"} {
+		if err := p.checkHost(host); !errors.Is(err, ErrHostNotAllowed) {
+			t.Errorf("checkHost(%q) = %v, want ErrHostNotAllowed", host, err)
+		}
+	}
+	for _, host := range []string{"8.8.8.8", "example.com", "2001:4860:4860::8888"} {
+		if err := p.checkHost(host); err != nil {
+			t.Errorf("checkHost(%q) = %v, want nil", host, err)
+		}
+	}
+}
+
+// roundTripperFunc is an http.RoundTripper that is not an *http.Transport.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestHostPolicyCustomTransport(t *testing.T) {
+	// A public host redirecting to one that resolves to a loopback address.
+	var requested []string
+	transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		requested = append(requested, req.URL.Host)
+		header := http.Header{"Location": []string{"http://localhost/latest/meta-data"}}
+		return &http.Response{StatusCode: http.StatusFound, Header: header, Body: http.NoBody, Request: req}, nil
+	})
+	m := New(WithHTTPClient(&http.Client{Transport: transport}))
+
+	_, err := m.ConvertURL("http://93.184.216.34/doc")
+	if !errors.Is(err, ErrHostNotAllowed) {
+		t.Errorf("err = %v, want ErrHostNotAllowed", err)
+	}
+	if len(requested) != 1 || requested[0] != "93.184.216.34" {
+		t.Errorf("requested hosts = %q, want only the public one", requested)
+	}
+}
+
+// spyConverter records the StreamInfo it is offered and declines it.
+type spyConverter struct{ info *StreamInfo }
+
+func (c *spyConverter) Accepts(info StreamInfo) bool {
+	*c.info = info
+	return false
+}
+
+func (c *spyConverter) Convert(reader io.ReadSeeker, info StreamInfo) (*DocumentConverterResult, error) {
+	return nil, errors.New("unreachable")
+}
+
 func TestNormalization(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -263,6 +1126,9 @@ func TestConverterAccepts(t *testing.T) {
 		{"xls by ext", NewXlsConverter(), StreamInfo{Extension: ".xls"}, true},
 		{"epub by ext", NewEpubConverter(nil), StreamInfo{Extension: ".epub"}, true},
 		{"zip by ext", NewZipConverter(nil), StreamInfo{Extension: ".zip"}, true},
+		{"image by ext", NewImageConverter(nil), StreamInfo{Extension: ".tiff"}, true},
+		{"image by mime", NewImageConverter(nil), StreamInfo{MIMEType: "image/jpeg"}, true},
+		{"image gif", NewImageConverter(nil), StreamInfo{Extension: ".gif"}, false},
 	}
 
 	for _, tt := range tests {
@@ -386,3 +1252,781 @@ func truncate(s string, maxLen int) string {
 	}
 	return s
 }
+
+func TestAssetWriter(t *testing.T) {
+	var pic bytes.Buffer
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	if err := png.Encode(&pic, img); err != nil {
+		t.Fatal(err)
+	}
+	dataURI := "data:image/png;base64," + base64.StdEncoding.EncodeToString(pic.Bytes())
+	sum := sha256.Sum256(pic.Bytes())
+	name := hex.EncodeToString(sum[:16]) + ".png"
+
+	dir := t.TempDir()
+	m := New(WithAssetWriter(&DirAssetWriter{Dir: dir, LinkPrefix: "assets"}))
+	link := "](assets/" + name + ")"
+
+	html := `<html><body><img src="` + dataURI + `" alt="a"><img src="` + dataURI + `" alt="b"></body></html>`
+	result, err := m.ConvertReader(strings.NewReader(html), StreamInfo{Extension: ".html"})
+	if err != nil {
+		t.Fatalf("html: %v", err)
+	}
+	if strings.Count(result.Markdown, link) != 2 || strings.Contains(result.Markdown, "base64") {
+		t.Errorf("html: expected two links to %s:\n%s", name, result.Markdown)
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, name)); err != nil || !bytes.Equal(data, pic.Bytes()) {
+		t.Errorf("html: asset not written: %v", err)
+	}
+
+	epub := zipBytes(t, map[string][]byte{
+		"mimetype":               []byte("application/epub+zip"),
+		"META-INF/container.xml": []byte(`<container><rootfiles><rootfile full-path="OEBPS/content.opf"/></rootfiles></container>`),
+		"OEBPS/content.opf": []byte(`<package><metadata/><manifest>` +
+			`<item id="c1" href="text/ch1.xhtml" media-type="application/xhtml+xml"/></manifest>` +
+			`<spine><itemref idref="c1"/></spine></package>`),
+		"OEBPS/text/ch1.xhtml": []byte(`<html><body><p>Chapter</p><img src="../images/dot.png" alt="dot"/></body></html>`),
+		"OEBPS/images/dot.png": pic.Bytes(),
+	})
+	result, err = m.ConvertReader(bytes.NewReader(epub), StreamInfo{Extension: ".epub"})
+	if err != nil {
+		t.Fatalf("epub: %v", err)
+	}
+	if !strings.Contains(result.Markdown, "![dot"+link) {
+		t.Errorf("epub: expected image link:\n%s", result.Markdown)
+	}
+
+	f := excelize.NewFile()
+	if err := f.SetCellValue("Sheet1", "A1", "Logo"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.AddPictureFromBytes("Sheet1", "B2", &excelize.Picture{
+		Extension: ".png", File: pic.Bytes(), Format: &excelize.GraphicOptions{AltText: "logo"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	xlsx, err := f.WriteToBuffer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err = m.ConvertReader(bytes.NewReader(xlsx.Bytes()), StreamInfo{Extension: ".xlsx"})
+	if err != nil {
+		t.Fatalf("xlsx: %v", err)
+	}
+	if !strings.Contains(result.Markdown, "![logo"+link) {
+		t.Errorf("xlsx: expected image link:\n%s", result.Markdown)
+	}
+
+	for _, file := range []string{"test.docx", "test.pptx"} {
+		result, err := m.ConvertFile(filepath.Join("testdata", file))
+		if err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+		if !strings.Contains(result.Markdown, "](assets/") || strings.Contains(result.Markdown, "](image)") {
+			t.Errorf("%s: expected asset links:\n%s", file, result.Markdown)
+		}
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 4 {
+		t.Errorf("got %d assets, want 4 (one shared, one DOCX, two PPTX)", len(entries))
+	}
+}
+
+// stubOCR is an OCREngine that returns text, or err if set, and records the
+// media types it was called with.
+type stubOCR struct {
+	text  string
+	err   error
+	calls []string
+}
+
+func (s *stubOCR) Recognize(ctx context.Context, image []byte, mediaType string) (string, error) {
+	if mediaType == "image/png" && !bytes.HasPrefix(image, []byte("\x89PNG")) {
+		return "", errors.New("not a PNG")
+	}
+	s.calls = append(s.calls, mediaType)
+	return s.text, s.err
+}
+
+// pdfBuilder assembles a PDF from objects numbered from 1 in the order
+// they are reserved or added.
+type pdfBuilder struct{ objs []string }
+
+func (b *pdfBuilder) reserve() int {
+	b.objs = append(b.objs, "")
+	return len(b.objs)
+}
+
+func (b *pdfBuilder) set(n int, obj string) { b.objs[n-1] = obj }
+
+func (b *pdfBuilder) add(obj string) int {
+	n := b.reserve()
+	b.set(n, obj)
+	return n
+}
+
+// page adds a page with the given content stream and extra dictionary
+// entries. Text can use the Helvetica font as /F1.
+func (b *pdfBuilder) page(parent, font int, content, extra string) int {
+	stream := b.add(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content)+1, content))
+	return b.add(fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 600 400] /Resources << /Font << /F1 %d 0 R >> >> /Contents %d 0 R %s>>",
+		parent, font, stream, extra))
+}
+
+// bytes serializes the PDF; trailer holds the trailer entries other than /Size.
+func (b *pdfBuilder) bytes(trailer string) []byte {
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(b.objs))
+	for i, obj := range b.objs {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(b.objs)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d %s >>\nstartxref\n%d\n%%%%EOF\n", len(b.objs)+1, trailer, xref)
+	return out.Bytes()
+}
+
+// testPDF returns a PDF with one page per content stream. Text can use the
+// Helvetica font as /F1.
+func testPDF(pages ...string) []byte {
+	var b pdfBuilder
+	catalog, parent := b.reserve(), b.reserve()
+	font := b.add("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>")
+	kids := make([]string, len(pages))
+	for i, content := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", b.page(parent, font, content, ""))
+	}
+	b.set(catalog, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", parent))
+	b.set(parent, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	return b.bytes(fmt.Sprintf("/Root %d 0 R", catalog))
+}
+
+// blankPDF returns a PDF with the given number of empty pages.
+func blankPDF(pages int) []byte {
+	return testPDF(make([]string, pages)...)
+}
+
+// pdfText returns a content stream fragment drawing s at (x, y).
+func pdfText(x, y float64, s string) string {
+	return pdfSizedText(x, y, 10, s)
+}
+
+// pdfSizedText is like pdfText with a font size.
+func pdfSizedText(x, y, size float64, s string) string {
+	return fmt.Sprintf("BT /F1 %g Tf %g %g Td (%s) Tj ET\n", size, x, y, s)
+}
+
+// pdfLine returns a content stream fragment stroking a line.
+func pdfLine(x1, y1, x2, y2 float64) string {
+	return fmt.Sprintf("%g %g m %g %g l S\n", x1, y1, x2, y2)
+}
+
+func TestOCR(t *testing.T) {
+	ocr := &stubOCR{text: "Scanned invoice 42"}
+	m := New(WithOCR(ocr))
+
+	result, err := m.ConvertReader(bytes.NewReader(blankPDF(2)), StreamInfo{Extension: ".pdf"})
+	if err != nil {
+		t.Fatalf("pdf: %v", err)
+	}
+	if strings.Count(result.Markdown, "Scanned invoice 42") != 2 || len(result.Segments) != 2 {
+		t.Errorf("pdf: expected OCR text for both pages:\n%s", result.Markdown)
+	}
+	if len(ocr.calls) != 2 || ocr.calls[0] != "image/png" {
+		t.Errorf("pdf: OCR calls = %q, want two PNG pages", ocr.calls)
+	}
+
+	// Pages with text are not rendered.
+	ocr.calls = nil
+	if _, err := m.ConvertFile("testdata/test.pdf"); err != nil {
+		t.Fatalf("text pdf: %v", err)
+	}
+	if len(ocr.calls) != 0 {
+		t.Errorf("text pdf: OCR calls = %q, want none", ocr.calls)
+	}
+
+	var pic bytes.Buffer
+	if err := png.Encode(&pic, image.NewRGBA(image.Rect(0, 0, 2, 2))); err != nil {
+		t.Fatal(err)
+	}
+	result, err = m.ConvertReader(bytes.NewReader(pic.Bytes()), StreamInfo{Extension: ".png"})
+	if err != nil {
+		t.Fatalf("png: %v", err)
+	}
+	if result.Markdown != "Scanned invoice 42" {
+		t.Errorf("png: markdown = %q", result.Markdown)
+	}
+	ocr.calls = nil
+	if _, err := m.ConvertReader(strings.NewReader("II*\x00tiff"), StreamInfo{Extension: ".tif"}); err != nil {
+		t.Fatalf("tiff: %v", err)
+	}
+	if len(ocr.calls) != 1 || ocr.calls[0] != "image/tiff" {
+		t.Errorf("tiff: OCR calls = %q", ocr.calls)
+	}
+
+	// Without an engine, images are unsupported and image-only PDFs are empty.
+	var unsupported *UnsupportedFormatError
+	if _, err := New().ConvertReader(bytes.NewReader(pic.Bytes()), StreamInfo{Extension: ".png"}); !errors.As(err, &unsupported) {
+		t.Errorf("png without OCR: err = %v, want UnsupportedFormatError", err)
+	}
+	result, err = New().ConvertReader(bytes.NewReader(blankPDF(1)), StreamInfo{Extension: ".pdf"})
+	if err != nil || !strings.Contains(result.Markdown, "No readable text") {
+		t.Errorf("pdf without OCR: %v\n%v", err, result)
+	}
+
+	// PDF pages that fail OCR are skipped with a warning.
+	result, err = New(WithOCR(&stubOCR{err: errors.New("ocr box down")})).ConvertReader(bytes.NewReader(blankPDF(1)), StreamInfo{Extension: ".pdf"})
+	if err != nil {
+		t.Fatalf("failing OCR: %v", err)
+	}
+	if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "ocr box down") {
+		t.Errorf("failing OCR: warnings = %q", result.Warnings)
+	}
+}
+
+func TestPdfTables(t *testing.T) {
+	// An invoice with an unruled table: columns are only aligned, amounts
+	// are right-aligned and one description wraps onto a second line.
+	var invoice strings.Builder
+	invoice.WriteString(pdfText(50, 360, "Invoice 2024-117 for services rendered in March."))
+	rows := [][]string{
+		{"Item", "Qty", "Amount"},
+		{"Consulting", "10", "1,500.00"},
+		{"Support plan", "1", "99.00"},
+		{"Travel", "2", "410.50"},
+	}
+	y := 320.0
+	for i, row := range rows {
+		invoice.WriteString(pdfText(50, y, row[0]))
+		invoice.WriteString(pdfText(250, y, row[1]))
+		invoice.WriteString(pdfText(400-float64(len(row[2]))*5, y, row[2]))
+		y -= 14
+		if i == 2 {
+			invoice.WriteString(pdfText(50, y, "(annual)"))
+			y -= 14
+		}
+	}
+	invoice.WriteString(pdfText(50, 200, "Payment is due within 30 days."))
+
+	// A statement with a ruled grid, including a cell with a pipe.
+	var statement strings.Builder
+	xs := []float64{50, 200, 350, 500}
+	ys := []float64{350, 330, 310, 290}
+	for _, x := range xs {
+		statement.WriteString(pdfLine(x, ys[0], x, ys[len(ys)-1]))
+	}
+	for _, y := range ys {
+		statement.WriteString(pdfLine(xs[0], y, xs[len(xs)-1], y))
+	}
+	cells := [][]string{{"Date", "Description", "Balance"}, {"01/03", "Opening", "100.00"}, {"05/03", "Fee a|b", "95.00"}}
+	for r, row := range cells {
+		for c, cell := range row {
+			statement.WriteString(pdfText(xs[c]+5, ys[r]-14, cell))
+		}
+	}
+
+	result, err := New().ConvertReader(bytes.NewReader(testPDF(invoice.String(), statement.String())), StreamInfo{Extension: ".pdf"})
+	if err != nil {
+		t.Fatalf("ConvertReader error: %v", err)
+	}
+	spaces := regexp.MustCompile(` +`)
+	md := spaces.ReplaceAllString(result.Markdown, " ")
+	for _, want := range []string{
+		"Invoice 2024-117 for services rendered in March.\n\n| Item | Qty | Amount |\n| --- | --- | --- |\n",
+		"| Consulting | 10 | 1,500.00 |\n| Support plan (annual) | 1 | 99.00 |\n| Travel | 2 | 410.50 |\n\nPayment is due",
+		"| Date | Description | Balance |\n| --- | --- | --- |\n| 01/03 | Opening | 100.00 |\n| 05/03 | Fee a\\|b | 95.00 |",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("missing %q in:\n%s", want, md)
+		}
+	}
+}
+
+func TestPdfColumns(t *testing.T) {
+	var page strings.Builder
+	page.WriteString(pdfText(150, 370, "Quarterly newsletter of the garden society"))
+	for i := range 5 {
+		y := 330 - float64(i)*14
+		page.WriteString(pdfText(50, y, fmt.Sprintf("Left column sentence number %d.", i+1)))
+		page.WriteString(pdfText(320, y, fmt.Sprintf("Right column sentence number %d.", i+1)))
+	}
+	pdf := testPDF(page.String())
+
+	result, err := New(WithPDFLayout(PDFLayoutColumns)).ConvertReader(bytes.NewReader(pdf), StreamInfo{Extension: ".pdf"})
+	if err != nil {
+		t.Fatalf("ConvertReader error: %v", err)
+	}
+	want := []string{"Quarterly newsletter"}
+	for i := range 5 {
+		want = append(want, fmt.Sprintf("Left column sentence number %d.", i+1))
+	}
+	for i := range 5 {
+		want = append(want, fmt.Sprintf("Right column sentence number %d.", i+1))
+	}
+	last := -1
+	for _, w := range want {
+		at := strings.Index(result.Markdown, w)
+		if at <= last {
+			t.Fatalf("%q out of reading order:\n%s", w, result.Markdown)
+		}
+		last = at
+	}
+
+	// The default single-column layout joins the columns line by line.
+	result, err = New().ConvertReader(bytes.NewReader(pdf), StreamInfo{Extension: ".pdf"})
+	if err != nil {
+		t.Fatalf("ConvertReader error: %v", err)
+	}
+	if strings.Index(result.Markdown, "Right column sentence number 1.") > strings.Index(result.Markdown, "Left column sentence number 2.") {
+		t.Errorf("expected interleaved columns by default:\n%s", result.Markdown)
+	}
+
+	// Tables are not mistaken for columns.
+	result, err = New(WithPDFLayout(PDFLayoutColumns)).ConvertReader(bytes.NewReader(testPDF(
+		pdfText(50, 300, "Item")+pdfText(300, 300, "Qty")+
+			pdfText(50, 286, "Consulting")+pdfText(300, 286, "10")+
+			pdfText(50, 272, "Support")+pdfText(300, 272, "1"))), StreamInfo{Extension: ".pdf"})
+	if err != nil {
+		t.Fatalf("ConvertReader error: %v", err)
+	}
+	if !strings.Contains(result.Markdown, "| Consulting") {
+		t.Errorf("expected a table:\n%s", result.Markdown)
+	}
+}
+
+func TestPdfOutlineLinksAndInfo(t *testing.T) {
+	var b pdfBuilder
+	catalog, parent, outlines, intro, details := b.reserve(), b.reserve(), b.reserve(), b.reserve(), b.reserve()
+	font := b.add("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>")
+
+	// "Read more at " is 63.36pt wide in 10pt Helvetica and "our website"
+	// 51.13pt, so the link covers just those words.
+	link := b.add("<< /Type /Annot /Subtype /Link /Rect [114 226 164 238] /Border [0 0 0] " +
+		"/A << /S /URI /URI (https://example.com/annual report) >> >>")
+	page1 := b.page(parent, font,
+		pdfSizedText(50, 350, 16, "Annual Report")+
+			pdfText(50, 300, "1 Introduction")+
+			pdfText(50, 280, "This report covers the fiscal year and the outlook for the next one.")+
+			pdfText(50, 230, "Read more at our website today."),
+		fmt.Sprintf("/Annots [%d 0 R] ", link))
+	page2 := b.page(parent, font,
+		pdfText(50, 350, "Details")+
+			pdfText(50, 330, "Revenue grew in every region, led by strong demand in the spring."),
+		"")
+	b.set(catalog, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R /Outlines %d 0 R >>", parent, outlines))
+	b.set(parent, fmt.Sprintf("<< /Type /Pages /Kids [%d 0 R %d 0 R] /Count 2 >>", page1, page2))
+	b.set(outlines, fmt.Sprintf("<< /Type /Outlines /First %d 0 R /Last %d 0 R /Count 2 >>", intro, intro))
+	b.set(intro, fmt.Sprintf("<< /Title (Introduction) /Parent %d 0 R /First %d 0 R /Last %d 0 R /Count 1 /Dest [%d 0 R /XYZ 0 310 0] >>",
+		outlines, details, details, page1))
+	b.set(details, fmt.Sprintf("<< /Title (Details) /Parent %d 0 R /Dest [%d 0 R /XYZ 0 360 0] >>", intro, page2))
+	info := b.add("<< /Title (Annual Report 2024) /Author (Jane Roe) /Subject (Finances) " +
+		"/Keywords (report, finance) /CreationDate (D:20240301120000Z) >>")
+	pdf := b.bytes(fmt.Sprintf("/Root %d 0 R /Info %d 0 R", catalog, info))
+
+	result, err := New(WithPDFTableOfContents(true)).ConvertReader(bytes.NewReader(pdf), StreamInfo{Extension: ".pdf"})
+	if err != nil {
+		t.Fatalf("ConvertReader error: %v", err)
+	}
+	md := result.Markdown
+	for _, want := range []string{
+		"# Contents\n\n- Introduction (page 1)\n  - Details (page 2)\n",
+		"Annual Report\n\n# 1 Introduction\n\nThis report covers",
+		"Read more at [our website](https://example.com/annual%20report) today.",
+		"## Details\n\nRevenue grew",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("missing %q in:\n%s", want, md)
+		}
+	}
+	if strings.Contains(md, "# Annual Report") {
+		t.Errorf("outline should replace font-size headings:\n%s", md)
+	}
+
+	if result.Title != "Annual Report 2024" {
+		t.Errorf("Title = %q", result.Title)
+	}
+	for key, want := range map[string]string{
+		MetadataAuthor:   "Jane Roe",
+		MetadataSubject:  "Finances",
+		MetadataKeywords: "report, finance",
+		MetadataCreated:  "2024-03-01T12:00:00Z",
+	} {
+		if got := result.Metadata[key]; got != want {
+			t.Errorf("Metadata[%s] = %q, want %q", key, got, want)
+		}
+	}
+
+	// Without the option there is no table of contents.
+	result, err = New().ConvertReader(bytes.NewReader(pdf), StreamInfo{Extension: ".pdf"})
+	if err != nil {
+		t.Fatalf("ConvertReader error: %v", err)
+	}
+	if strings.Contains(result.Markdown, "Contents") || !strings.Contains(result.Markdown, "# 1 Introduction") {
+		t.Errorf("unexpected output without table of contents:\n%s", result.Markdown)
+	}
+}
+
+func TestPdfOutlineCycles(t *testing.T) {
+	// outlinePDF returns a one-page PDF whose outline items are given by
+	// items, which are formatted with the object numbers of the outline
+	// root, the page and the items themselves.
+	outlinePDF := func(items ...string) []byte {
+		var b pdfBuilder
+		catalog, parent, outlines := b.reserve(), b.reserve(), b.reserve()
+		ids := make([]int, len(items))
+		for i := range items {
+			ids[i] = b.reserve()
+		}
+		font := b.add("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>")
+		page := b.page(parent, font, pdfText(50, 350, "Loop")+pdfText(50, 300, "Body text of the page."), "")
+		b.set(catalog, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R /Outlines %d 0 R >>", parent, outlines))
+		b.set(parent, fmt.Sprintf("<< /Type /Pages /Kids [%d 0 R] /Count 1 >>", page))
+		b.set(outlines, fmt.Sprintf("<< /Type /Outlines /First %d 0 R /Last %d 0 R /Count %d >>", ids[0], ids[len(ids)-1], len(ids)))
+		for i, item := range items {
+			args := []any{outlines, page}
+			for _, id := range ids {
+				args = append(args, id)
+			}
+			b.set(ids[i], fmt.Sprintf(item, args...))
+		}
+		return b.bytes(fmt.Sprintf("/Root %d 0 R", catalog))
+	}
+
+	tests := []struct {
+		name string
+		pdf  []byte
+		want string // reason given in the warning
+	}{
+		{
+			"self as first child",
+			outlinePDF("<< /Title (Loop) /Parent %[1]d 0 R /First %[3]d 0 R /Last %[3]d 0 R /Count 1 /Dest [%[2]d 0 R /XYZ 0 360 0] >>"),
+			"levels deep",
+		},
+		{
+			"sibling cycle",
+			outlinePDF("<< /Title (First) /Parent %[1]d 0 R /Next %[4]d 0 R /Dest [%[2]d 0 R /XYZ 0 360 0] >>",
+				"<< /Title (Second) /Parent %[1]d 0 R /Next %[3]d 0 R >>"),
+			"entries",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := New(WithPDFTableOfContents(true)).ConvertReader(bytes.NewReader(tt.pdf), StreamInfo{Extension: ".pdf"})
+			if err != nil {
+				t.Fatalf("ConvertReader error: %v", err)
+			}
+			if result.Markdown != "Loop\n\nBody text of the page." {
+				t.Errorf("markdown = %q, want the page text without the outline", result.Markdown)
+			}
+			if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "PDF outline ignored") || !strings.Contains(result.Warnings[0], tt.want) {
+				t.Errorf("warnings = %q, want the outline ignored for %q", result.Warnings, tt.want)
+			}
+		})
+	}
+}
+
+func TestPdfPagesAndMarkers(t *testing.T) {
+	var pages []string
+	for i := range 4 {
+		pages = append(pages, pdfText(50, 350, fmt.Sprintf("Content of page %d.", i+1)))
+	}
+	pdf := testPDF(pages...)
+
+	m := New(WithPDFPages("3-, 1"), WithPDFPageMarkers(true))
+	result, err := m.ConvertReader(bytes.NewReader(pdf), StreamInfo{Extension: ".pdf"})
+	if err != nil {
+		t.Fatalf("ConvertReader error: %v", err)
+	}
+	want := "<!-- Page 1 -->\n\nContent of page 1.\n\n<!-- Page 3 -->\n\nContent of page 3.\n\n<!-- Page 4 -->\n\nContent of page 4."
+	if result.Markdown != want {
+		t.Errorf("markdown = %q, want %q", result.Markdown, want)
+	}
+	if len(result.Segments) != 3 || result.Segments[1].Number != 3 || result.Segments[1].Markdown != "Content of page 3." {
+		t.Errorf("segments = %+v", result.Segments)
+	}
+	if result.Metadata[MetadataPageCount] != "4" {
+		t.Errorf("page_count = %q, want the document's page count", result.Metadata[MetadataPageCount])
+	}
+
+	// The page limit applies to the selected pages.
+	if _, err := New(WithPDFPages("2"), WithMaxPages(1)).ConvertReader(bytes.NewReader(pdf), StreamInfo{Extension: ".pdf"}); err != nil {
+		t.Errorf("one selected page under a one-page limit: %v", err)
+	}
+
+	for _, spec := range []string{"0", "2-1", "a", "1,,2"} {
+		if _, err := New(WithPDFPages(spec)).ConvertReader(bytes.NewReader(pdf), StreamInfo{Extension: ".pdf"}); err == nil || !strings.Contains(err.Error(), "invalid page range") {
+			t.Errorf("WithPDFPages(%q): err = %v, want invalid page range", spec, err)
+		}
+	}
+}
+
+func TestPdfRunningHeadersAndParagraphs(t *testing.T) {
+	body := [][]string{
+		{"Quarterly results show that the conver-", "sion rate improved across all regions and"},
+		{"continued to grow in the second half. The team", "expects further gains from the new pric-"},
+		{"ing model next year."},
+	}
+	var pages []string
+	for i, lines := range body {
+		page := pdfText(50, 380, "ACME Corp Confidential")
+		for j, line := range lines {
+			page += pdfText(50, 340-12*float64(j), line)
+		}
+		if i == 2 {
+			page += pdfText(50, 300, "Summary follows.")
+		}
+		page += pdfText(180, 20, fmt.Sprintf("Page %d of 3", i+1))
+		pages = append(pages, page)
+	}
+	pdf := testPDF(pages...)
+
+	result, err := New().ConvertReader(bytes.NewReader(pdf), StreamInfo{Extension: ".pdf"})
+	if err != nil {
+		t.Fatalf("ConvertReader error: %v", err)
+	}
+	want := "Quarterly results show that the conversion rate improved across all regions and\n" +
+		"continued to grow in the second half. The team\n" +
+		"expects further gains from the new pricing model next year.\n\n" +
+		"Summary follows."
+	if result.Markdown != want {
+		t.Errorf("markdown = %q, want %q", result.Markdown, want)
+	}
+	if len(result.Segments) != 3 {
+		t.Errorf("segments = %+v, want one per page", result.Segments)
+	}
+
+	result, err = New(WithHeadersFooters(true)).ConvertReader(bytes.NewReader(pdf), StreamInfo{Extension: ".pdf"})
+	if err != nil {
+		t.Fatalf("ConvertReader error: %v", err)
+	}
+	for _, s := range []string{"ACME Corp Confidential", "Page 2 of 3"} {
+		if !strings.Contains(result.Markdown, s) {
+			t.Errorf("WithHeadersFooters(true): %q missing from %q", s, result.Markdown)
+		}
+	}
+}
+
+func TestPdfHyphenation(t *testing.T) {
+	var b pdfBuilder
+	catalog, parent := b.reserve(), b.reserve()
+	fonts := fmt.Sprintf("/F1 %d 0 R /F2 %d 0 R /F3 %d 0 R",
+		b.add("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>"),
+		b.add("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Oblique >>"),
+		b.add("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold >>"))
+	content := "BT /F1 10 Tf 50 340 Td (Both steps use a fusion of natural and ) Tj /F2 10 Tf (pro-) Tj ET\n" +
+		"BT /F1 10 Tf 50 328 Td (gramming languages. Agents share the same ) Tj /F3 10 Tf (informa-) Tj ET\n" +
+		pdfText(50, 316, "tion through chat-") +
+		pdfText(50, 304, "optimized models and conversation-") +
+		pdfText(50, 292, "centric control. Programming runs end-to-") +
+		pdfText(50, 280, "end over low-") +
+		pdfText(50, 268, "level links and two-") +
+		pdfText(50, 256, "way calls.")
+	stream := b.add(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content)+1, content))
+	page := b.add(fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 600 400] /Resources << /Font << %s >> >> /Contents %d 0 R >>",
+		parent, fonts, stream))
+	b.set(catalog, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", parent))
+	b.set(parent, fmt.Sprintf("<< /Type /Pages /Kids [%d 0 R] /Count 1 >>", page))
+
+	result, err := New().ConvertReader(bytes.NewReader(b.bytes(fmt.Sprintf("/Root %d 0 R", catalog))), StreamInfo{Extension: ".pdf"})
+	if err != nil {
+		t.Fatalf("ConvertReader error: %v", err)
+	}
+	// Emphasis closing a hyphenated word does not keep it apart, and
+	// compounds broken at their hyphen keep it, however short their halves.
+	want := "Both steps use a fusion of natural and *pro*gramming languages. Agents share the same **informa**tion through chat-optimized models and conversation-centric control. " +
+		"Programming runs end-to-end over low-level links and two-way calls."
+	if result.Markdown != want {
+		t.Errorf("markdown = %q, want %q", result.Markdown, want)
+	}
+}
+
+// encryptedPDF returns a one-page PDF encrypted with the 40-bit RC4
+// standard security handler (revision 2) and the given user password.
+func encryptedPDF(t *testing.T, password, text string) []byte {
+	t.Helper()
+	padding := []byte("\x28\xbf\x4e\x5e\x4e\x75\x8a\x41\x64\x00\x4e\x56\xff\xfa\x01\x08" +
+		"\x2e\x2e\x00\xb6\xd0\x68\x3e\x80\x2f\x0c\xa9\xfe\x64\x53\x69\x7a")
+	pad := func(pw string) []byte { return append([]byte(pw), padding...)[:32] }
+	crypt := func(key, data []byte) []byte {
+		c, err := rc4.NewCipher(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out := make([]byte, len(data))
+		c.XORKeyStream(out, data)
+		return out
+	}
+
+	ownerKey := md5.Sum(pad("owner"))
+	o := crypt(ownerKey[:5], pad(password))
+	id := []byte("0123456789abcdef")
+	h := md5.New()
+	h.Write(pad(password))
+	h.Write(o)
+	h.Write([]byte{0xfc, 0xff, 0xff, 0xff}) // /P -4
+	h.Write(id)
+	key := h.Sum(nil)[:5]
+	u := crypt(key, padding)
+
+	var b pdfBuilder
+	catalog, parent := b.reserve(), b.reserve()
+	font := b.add("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>")
+	stream := b.reserve()
+	objKey := md5.Sum(append(append([]byte{}, key...), byte(stream), 0, 0, 0, 0))
+	content := string(crypt(objKey[:10], []byte(pdfText(50, 350, text))))
+	b.set(stream, fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content))
+	page := b.add(fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 600 400] /Resources << /Font << /F1 %d 0 R >> >> /Contents %d 0 R >>",
+		parent, font, stream))
+	b.set(catalog, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", parent))
+	b.set(parent, fmt.Sprintf("<< /Type /Pages /Kids [%d 0 R] /Count 1 >>", page))
+	encrypt := b.add(fmt.Sprintf("<< /Filter /Standard /V 1 /R 2 /O <%x> /U <%x> /P -4 >>", o, u))
+	return b.bytes(fmt.Sprintf("/Root %d 0 R /Encrypt %d 0 R /ID [<%x> <%x>]", catalog, encrypt, id, id))
+}
+
+func TestPdfPassword(t *testing.T) {
+	pdf := encryptedPDF(t, "secret", "Confidential figures.")
+	m := New()
+
+	for _, tt := range []struct {
+		password  string
+		incorrect bool
+	}{{"", false}, {"wrong", true}} {
+		_, err := m.ConvertReader(bytes.NewReader(pdf), StreamInfo{Extension: ".pdf", Password: tt.password})
+		var pwErr *PasswordRequiredError
+		if !errors.As(err, &pwErr) || !IsPasswordRequired(err) {
+			t.Errorf("password %q: err = %v, want PasswordRequiredError", tt.password, err)
+			continue
+		}
+		if pwErr.Incorrect != tt.incorrect {
+			t.Errorf("password %q: Incorrect = %v, want %v", tt.password, pwErr.Incorrect, tt.incorrect)
+		}
+	}
+
+	result, err := m.ConvertReader(bytes.NewReader(pdf), StreamInfo{Extension: ".pdf", Password: "secret"})
+	if err != nil {
+		t.Fatalf("ConvertReader error: %v", err)
+	}
+	if result.Markdown != "Confidential figures." {
+		t.Errorf("markdown = %q", result.Markdown)
+	}
+
+	m = New(WithPassword("secret"))
+	result, err = m.ConvertReader(bytes.NewReader(pdf), StreamInfo{Extension: ".pdf"})
+	if err != nil {
+		t.Fatalf("ConvertReader with WithPassword error: %v", err)
+	}
+	if result.Markdown != "Confidential figures." {
+		t.Errorf("markdown with WithPassword = %q", result.Markdown)
+	}
+	_, err = m.ConvertReader(bytes.NewReader(pdf), StreamInfo{Extension: ".pdf", Password: "wrong"})
+	if !IsPasswordRequired(err) {
+		t.Errorf("StreamInfo.Password did not override WithPassword: err = %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "encrypted.pdf")
+	if err := os.WriteFile(path, pdf, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	result, err = New().ConvertFileContext(ContextWithPassword(context.Background(), "secret"), path)
+	if err != nil {
+		t.Fatalf("ConvertFileContext with ContextWithPassword error: %v", err)
+	}
+	if result.Markdown != "Confidential figures." {
+		t.Errorf("markdown with ContextWithPassword = %q", result.Markdown)
+	}
+	_, err = m.ConvertFileContext(ContextWithPassword(context.Background(), "wrong"), path)
+	if !IsPasswordRequired(err) {
+		t.Errorf("ContextWithPassword did not override WithPassword: err = %v", err)
+	}
+}
+
+func TestPdfWorkers(t *testing.T) {
+	m := New(WithPDFWorkers(3), WithPDFAcquireTimeout(100*time.Millisecond))
+
+	var wg sync.WaitGroup
+	errs := make([]error, 6)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			want := fmt.Sprintf("Document number %d.", i)
+			result, err := m.ConvertReader(bytes.NewReader(testPDF(pdfText(72, 720, want))), StreamInfo{Extension: ".pdf"})
+			if err == nil && result.Markdown != want {
+				err = fmt.Errorf("markdown = %q, want %q", result.Markdown, want)
+			}
+			errs[i] = err
+		}()
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("conversion %d: %v", i, err)
+		}
+	}
+
+	// With every instance taken, a conversion gives up after the acquire
+	// timeout.
+	var releases []func()
+	for range 3 {
+		_, release, err := m.pdfium.acquire(context.Background(), 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		releases = append(releases, release)
+	}
+	start := time.Now()
+	if _, err := m.ConvertReader(bytes.NewReader(testPDF(pdfText(72, 720, "Waiting."))), StreamInfo{Extension: ".pdf"}); err == nil || !strings.Contains(err.Error(), "get pdfium instance") {
+		t.Errorf("conversion with no free instance: err = %v, want acquire timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("conversion waited %v for an instance", elapsed)
+	}
+
+	// Closing with instances in use shuts the pool down when the last one
+	// is released.
+	if err := m.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if err := m.Close(); err != nil {
+		t.Errorf("second Close: %v", err)
+	}
+	if _, err := m.ConvertReader(bytes.NewReader(testPDF(pdfText(72, 720, "Closed."))), StreamInfo{Extension: ".pdf"}); !errors.Is(err, ErrClosed) {
+		t.Errorf("conversion after Close: err = %v, want ErrClosed", err)
+	}
+	for i, release := range releases {
+		m.pdfium.mu.Lock()
+		running := m.pdfium.pool != nil
+		m.pdfium.mu.Unlock()
+		if !running {
+			t.Fatalf("pool shut down with %d instances in use", len(releases)-i)
+		}
+		release()
+	}
+	if m.pdfium.pool != nil {
+		t.Error("pool still running after the last instance was released")
+	}
+
+	// Without WithPDFWorkers the package's instances are shared, and
+	// closing one MarkItDown leaves them to the others.
+	a, b := New(), New()
+	if a.pdfium != defaultPdfiumPool || b.pdfium != defaultPdfiumPool {
+		t.Fatal("MarkItDown without WithPDFWorkers has its own pool")
+	}
+	if err := a.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if _, err := a.ConvertReader(bytes.NewReader(testPDF(pdfText(72, 720, "Closed."))), StreamInfo{Extension: ".pdf"}); !errors.Is(err, ErrClosed) {
+		t.Errorf("conversion after Close: err = %v, want ErrClosed", err)
+	}
+	if result, err := b.ConvertReader(bytes.NewReader(testPDF(pdfText(72, 720, "Shared."))), StreamInfo{Extension: ".pdf"}); err != nil || result.Markdown != "Shared." {
+		t.Errorf("conversion with the shared pool: %v", err)
+	}
+}
@@ -0,0 +1,368 @@
+// Copyright 2026 Conductor OSS
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+
+// Package mcp implements a Model Context Protocol server that exposes
+// MarkItDown conversion as tools over the stdio transport (newline-delimited
+// JSON-RPC 2.0).
+//
+// Tools:
+//
+//	convert_to_markdown  convert a file path, file:// or http(s) URL, or a base64 payload
+//	list_formats         list the registered converters and the formats they accept
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	markitdown "github.com/conductor-oss/markitdown"
+)
+
+// ProtocolVersion is the MCP revision this server implements.
+const ProtocolVersion = "2024-11-05"
+
+// JSON-RPC error codes.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// Server serves MCP requests for a MarkItDown instance.
+type Server struct {
+	markitdown *markitdown.MarkItDown
+	version    string
+
+	writeMu sync.Mutex
+	enc     *json.Encoder
+
+	mu       sync.Mutex
+	inFlight map[string]context.CancelFunc
+}
+
+// NewServer creates a Server. version is reported in the initialize response.
+func NewServer(m *markitdown.MarkItDown, version string) *Server {
+	return &Server{
+		markitdown: m,
+		version:    version,
+		inFlight:   make(map[string]context.CancelFunc),
+	}
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Serve reads requests from r and writes responses to w until r is exhausted
+// or ctx is done. Requests are handled concurrently; in-flight tool calls are
+// cancelled by "notifications/cancelled" or when Serve returns.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	var wg sync.WaitGroup
+	ctx, cancel := context.WithCancel(ctx)
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	s.enc = json.NewEncoder(w)
+	br := bufio.NewReader(r)
+
+	lines := make(chan []byte)
+	readErr := make(chan error, 1)
+	go func() {
+		defer close(lines)
+		for {
+			line, err := br.ReadBytes('\n')
+			if len(bytes.TrimSpace(line)) > 0 {
+				select {
+				case lines <- line:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err != nil {
+				if err != io.EOF {
+					readErr <- err
+				}
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case line, ok := <-lines:
+			if !ok {
+				select {
+				case err := <-readErr:
+					return err
+				default:
+					return nil
+				}
+			}
+			var req request
+			if err := json.Unmarshal(line, &req); err != nil {
+				s.write(response{ID: json.RawMessage("null"), Error: &rpcError{Code: codeParseError, Message: err.Error()}})
+				continue
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				s.handle(ctx, req)
+			}()
+		}
+	}
+}
+
+func (s *Server) write(resp response) {
+	resp.JSONRPC = "2.0"
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	_ = s.enc.Encode(resp)
+}
+
+// handle dispatches one request. Notifications (no id) never get a response.
+func (s *Server) handle(ctx context.Context, req request) {
+	isNotification := len(req.ID) == 0
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		if !isNotification {
+			s.write(response{ID: req.ID, Error: &rpcError{Code: codeInvalidRequest, Message: "invalid request"}})
+		}
+		return
+	}
+
+	var result any
+	var rerr *rpcError
+	switch req.Method {
+	case "initialize":
+		result = s.initialize(req.Params)
+	case "ping":
+		result = struct{}{}
+	case "tools/list":
+		result = map[string]any{"tools": tools}
+	case "tools/call":
+		callCtx, cancel := context.WithCancel(ctx)
+		key := string(req.ID)
+		s.mu.Lock()
+		s.inFlight[key] = cancel
+		s.mu.Unlock()
+		result, rerr = s.callTool(callCtx, req.Params)
+		s.mu.Lock()
+		delete(s.inFlight, key)
+		s.mu.Unlock()
+		cancel()
+	case "notifications/cancelled":
+		var p struct {
+			RequestID json.RawMessage `json:"requestId"`
+		}
+		if json.Unmarshal(req.Params, &p) == nil {
+			s.mu.Lock()
+			if cancel, ok := s.inFlight[string(p.RequestID)]; ok {
+				cancel()
+			}
+			s.mu.Unlock()
+		}
+	case "notifications/initialized":
+	default:
+		rerr = &rpcError{Code: codeMethodNotFound, Message: "method not found: " + req.Method}
+	}
+
+	if isNotification {
+		return
+	}
+	if rerr != nil {
+		s.write(response{ID: req.ID, Error: rerr})
+		return
+	}
+	s.write(response{ID: req.ID, Result: result})
+}
+
+func (s *Server) initialize(params json.RawMessage) any {
+	version := ProtocolVersion
+	var p struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}
+	if json.Unmarshal(params, &p) == nil && p.ProtocolVersion != "" && p.ProtocolVersion < ProtocolVersion {
+		version = p.ProtocolVersion
+	}
+	return map[string]any{
+		"protocolVersion": version,
+		"capabilities":    map[string]any{"tools": map[string]any{}},
+		"serverInfo":      map[string]any{"name": "markitdown", "version": s.version},
+	}
+}
+
+// tool describes an MCP tool in tools/list.
+type tool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	InputSchema map[string]any `json:"inputSchema"`
+}
+
+var tools = []tool{
+	{
+		Name: "convert_to_markdown",
+		Description: "Convert a document to Markdown. Provide either source (a local file path, file:// URI " +
+			"or http(s) URL) or content_base64 (the document bytes) with a filename or extension hint.",
+		InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"source":         map[string]any{"type": "string", "description": "File path, file:// URI or http(s) URL"},
+				"content_base64": map[string]any{"type": "string", "description": "Base64-encoded document bytes"},
+				"filename":       map[string]any{"type": "string", "description": "Filename hint for content_base64"},
+				"extension":      map[string]any{"type": "string", "description": "Extension hint, e.g. \".pdf\""},
+				"mime_type":      map[string]any{"type": "string", "description": "MIME type hint"},
+				"charset":        map[string]any{"type": "string", "description": "Charset hint, e.g. \"shift_jis\""},
+				"password":       map[string]any{"type": "string", "description": "Password of an encrypted document (PDF)"},
+			},
+		},
+	},
+	{
+		Name:        "list_formats",
+		Description: "List the registered converters and the file extensions and MIME types they accept.",
+		InputSchema: map[string]any{"type": "object", "properties": map[string]any{}},
+	},
+}
+
+type convertArgs struct {
+	Source        string `json:"source"`
+	ContentBase64 string `json:"content_base64"`
+	Filename      string `json:"filename"`
+	Extension     string `json:"extension"`
+	MIMEType      string `json:"mime_type"`
+	Charset       string `json:"charset"`
+	Password      string `json:"password"`
+}
+
+// textContent is an MCP text content block.
+type textContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// toolResult is the result of tools/call. Tool failures are reported in-band
+// with IsError set, as the protocol requires.
+type toolResult struct {
+	Content []textContent `json:"content"`
+	IsError bool          `json:"isError,omitempty"`
+}
+
+func (s *Server) callTool(ctx context.Context, params json.RawMessage) (any, *rpcError) {
+	var p struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: err.Error()}
+	}
+
+	switch p.Name {
+	case "convert_to_markdown":
+		var args convertArgs
+		if len(p.Arguments) > 0 {
+			if err := json.Unmarshal(p.Arguments, &args); err != nil {
+				return nil, &rpcError{Code: codeInvalidParams, Message: err.Error()}
+			}
+		}
+		result, err := s.convert(ctx, args)
+		if err != nil {
+			return toolResult{Content: []textContent{{Type: "text", Text: err.Error()}}, IsError: true}, nil
+		}
+		return toolResult{Content: []textContent{{Type: "text", Text: result.Markdown}}}, nil
+
+	case "list_formats":
+		var b strings.Builder
+		for _, f := range s.markitdown.Formats() {
+			fmt.Fprintf(&b, "- %s", f.Converter)
+			if len(f.Extensions) > 0 {
+				fmt.Fprintf(&b, ": %s", strings.Join(f.Extensions, ", "))
+			}
+			if len(f.MIMETypes) > 0 {
+				fmt.Fprintf(&b, " (%s)", strings.Join(f.MIMETypes, ", "))
+			}
+			b.WriteString("\n")
+		}
+		return toolResult{Content: []textContent{{Type: "text", Text: b.String()}}}, nil
+
+	default:
+		return nil, &rpcError{Code: codeInvalidParams, Message: "unknown tool: " + p.Name}
+	}
+}
+
+func (s *Server) convert(ctx context.Context, args convertArgs) (*markitdown.DocumentConverterResult, error) {
+	if args.Password != "" {
+		ctx = markitdown.ContextWithPassword(ctx, args.Password)
+	}
+	switch {
+	case args.Source != "" && args.ContentBase64 != "":
+		return nil, errors.New("provide either source or content_base64, not both")
+
+	case args.ContentBase64 != "":
+		data, err := base64.StdEncoding.DecodeString(args.ContentBase64)
+		if err != nil {
+			return nil, fmt.Errorf("decode content_base64: %w", err)
+		}
+		info := markitdown.StreamInfo{
+			Filename:  args.Filename,
+			Extension: strings.ToLower(args.Extension),
+			MIMEType:  args.MIMEType,
+			Charset:   args.Charset,
+		}
+		if info.Extension == "" {
+			info.Extension = strings.ToLower(filepath.Ext(args.Filename))
+		}
+		if info.Extension != "" && !strings.HasPrefix(info.Extension, ".") {
+			info.Extension = "." + info.Extension
+		}
+		return s.markitdown.ConvertReaderContext(ctx, bytes.NewReader(data), info)
+
+	case strings.HasPrefix(args.Source, "file://"):
+		u, err := url.Parse(args.Source)
+		if err != nil {
+			return nil, fmt.Errorf("parse source: %w", err)
+		}
+		return s.markitdown.ConvertFileContext(ctx, filepath.FromSlash(u.Path))
+
+	case args.Source != "":
+		return s.markitdown.ConvertContext(ctx, args.Source)
+
+	default:
+		return nil, errors.New("source or content_base64 is required")
+	}
+}
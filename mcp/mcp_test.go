@@ -0,0 +1,212 @@
+// Copyright 2026 Conductor OSS
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	markitdown "github.com/conductor-oss/markitdown"
+)
+
+// stdioClient drives a Server over pipes the way an MCP host would.
+type stdioClient struct {
+	t      *testing.T
+	in     *io.PipeWriter
+	out    *bufio.Reader
+	nextID int
+}
+
+func newStdioClient(t *testing.T) *stdioClient {
+	t.Helper()
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	srv := NewServer(markitdown.New(), "test")
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- srv.Serve(ctx, inR, outW)
+		outW.Close()
+	}()
+	t.Cleanup(func() {
+		inW.Close()
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Errorf("Serve returned %v", err)
+			}
+		case <-time.After(10 * time.Second):
+			t.Errorf("Serve did not return after stdin closed")
+		}
+		cancel()
+	})
+	return &stdioClient{t: t, in: inW, out: bufio.NewReader(outR)}
+}
+
+func (c *stdioClient) send(v any) {
+	c.t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	if _, err := c.in.Write(append(data, '\n')); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+// call sends a request and returns its response.
+func (c *stdioClient) call(method string, params any) map[string]any {
+	c.t.Helper()
+	c.nextID++
+	c.send(map[string]any{"jsonrpc": "2.0", "id": c.nextID, "method": method, "params": params})
+	line, err := c.out.ReadBytes('\n')
+	if err != nil {
+		c.t.Fatalf("read response: %v", err)
+	}
+	var resp map[string]any
+	if err := json.Unmarshal(line, &resp); err != nil {
+		c.t.Fatalf("decode response %q: %v", line, err)
+	}
+	if id, _ := resp["id"].(float64); int(id) != c.nextID {
+		c.t.Fatalf("response id = %v, want %d", resp["id"], c.nextID)
+	}
+	return resp
+}
+
+// toolText calls a tool and returns its text content and isError flag.
+func (c *stdioClient) toolText(name string, args map[string]any) (string, bool) {
+	c.t.Helper()
+	resp := c.call("tools/call", map[string]any{"name": name, "arguments": args})
+	result, ok := resp["result"].(map[string]any)
+	if !ok {
+		c.t.Fatalf("tools/call %s: no result: %v", name, resp)
+	}
+	content := result["content"].([]any)
+	isError, _ := result["isError"].(bool)
+	return content[0].(map[string]any)["text"].(string), isError
+}
+
+// blockingConverter accepts ".block" files and waits for its context.
+type blockingConverter struct {
+	started chan struct{}
+}
+
+func (c *blockingConverter) Accepts(info markitdown.StreamInfo) bool {
+	return info.Extension == ".block"
+}
+
+func (c *blockingConverter) Convert(r io.ReadSeeker, info markitdown.StreamInfo) (*markitdown.DocumentConverterResult, error) {
+	return c.ConvertContext(context.Background(), r, info)
+}
+
+func (c *blockingConverter) ConvertContext(ctx context.Context, _ io.ReadSeeker, _ markitdown.StreamInfo) (*markitdown.DocumentConverterResult, error) {
+	close(c.started)
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestServeCancelsInFlightCalls(t *testing.T) {
+	conv := &blockingConverter{started: make(chan struct{})}
+	m := markitdown.New()
+	m.RegisterConverter("block", conv, 0)
+
+	inR, inW := io.Pipe()
+	done := make(chan error, 1)
+	go func() { done <- NewServer(m, "test").Serve(context.Background(), inR, io.Discard) }()
+
+	call, _ := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "method": "tools/call", "params": map[string]any{
+		"name":      "convert_to_markdown",
+		"arguments": map[string]any{"content_base64": base64.StdEncoding.EncodeToString([]byte("x")), "filename": "a.block"},
+	}})
+	if _, err := inW.Write(append(call, '\n')); err != nil {
+		t.Fatal(err)
+	}
+	<-conv.started
+	inW.Close()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Serve returned %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve waited for an in-flight tool call instead of cancelling it")
+	}
+}
+
+func TestStdioSession(t *testing.T) {
+	c := newStdioClient(t)
+
+	resp := c.call("initialize", map[string]any{
+		"protocolVersion": ProtocolVersion,
+		"clientInfo":      map[string]any{"name": "test", "version": "1"},
+		"capabilities":    map[string]any{},
+	})
+	result := resp["result"].(map[string]any)
+	if result["protocolVersion"] != ProtocolVersion {
+		t.Errorf("protocolVersion = %v", result["protocolVersion"])
+	}
+	c.send(map[string]any{"jsonrpc": "2.0", "method": "notifications/initialized"})
+
+	resp = c.call("tools/list", map[string]any{})
+	var names []string
+	for _, tl := range resp["result"].(map[string]any)["tools"].([]any) {
+		names = append(names, tl.(map[string]any)["name"].(string))
+	}
+	if strings.Join(names, ",") != "convert_to_markdown,list_formats" {
+		t.Errorf("tools = %v", names)
+	}
+
+	text, isErr := c.toolText("list_formats", nil)
+	if isErr || !strings.Contains(text, "- pdf: .pdf (application/pdf)") {
+		t.Errorf("list_formats = %q", text)
+	}
+
+	text, isErr = c.toolText("convert_to_markdown", map[string]any{
+		"content_base64": base64.StdEncoding.EncodeToString([]byte("a,b\n1,2\n")),
+		"filename":       "data.csv",
+	})
+	if isErr || !strings.Contains(text, "| a | b |") {
+		t.Errorf("convert base64 = %q (isError=%v)", text, isErr)
+	}
+
+	text, isErr = c.toolText("convert_to_markdown", map[string]any{"source": "../testdata/test.docx"})
+	if isErr || !strings.Contains(text, "# Abstract") {
+		t.Errorf("convert path = %.200q (isError=%v)", text, isErr)
+	}
+
+	text, isErr = c.toolText("convert_to_markdown", map[string]any{"source": "../testdata/test_encrypted.pdf", "password": "secret"})
+	if isErr || text != "Confidential figures." {
+		t.Errorf("convert encrypted path = %q (isError=%v)", text, isErr)
+	}
+
+	text, isErr = c.toolText("convert_to_markdown", map[string]any{"source": "../testdata/test_encrypted.pdf"})
+	if !isErr || !strings.Contains(text, "password") {
+		t.Errorf("convert encrypted path without password = %q (isError=%v)", text, isErr)
+	}
+
+	text, isErr = c.toolText("convert_to_markdown", map[string]any{"source": "../testdata/missing.pdf"})
+	if !isErr || !strings.Contains(text, "missing.pdf") {
+		t.Errorf("convert missing file = %q (isError=%v)", text, isErr)
+	}
+
+	resp = c.call("no/such/method", nil)
+	if rerr, ok := resp["error"].(map[string]any); !ok || rerr["code"].(float64) != codeMethodNotFound {
+		t.Errorf("unknown method response = %v", resp)
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright 2026 Conductor OSS
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+
+package markitdown
+
+import "context"
+
+// OCREngine recognizes text in images, such as scanned PDF pages. Set one
+// with WithOCR. No engine is built in; implementations typically call a
+// local OCR library or an OCR service.
+type OCREngine interface {
+	// Recognize returns the text in image, whose media type is mediaType
+	// (e.g. "image/png"). The text is used as Markdown as is.
+	Recognize(ctx context.Context, image []byte, mediaType string) (string, error)
+}
+
+// ocrDPI is the resolution at which PDF pages are rendered for OCR.
+const ocrDPI = 300
@@ -11,6 +11,11 @@
 
 package markitdown
 
+import (
+	"net/http"
+	"time"
+)
+
 // Option configures a MarkItDown instance.
 type Option func(*MarkItDown)
 
@@ -22,9 +27,262 @@ func WithKeepDataURIs(keep bool) Option {
 	}
 }
 
-// WithStyleMap sets custom style mapping for DOCX conversion.
+// WithStyleMap sets custom style mapping for DOCX conversion, using a
+// mammoth-style mapping language with one rule per line:
+//
+//	p[style-name='Code Block'] => pre
+//	p[style-name='Callout'] => blockquote > p
+//	p.TitleBig => h1:fresh
+//	r[style-name='Emphasis'] => em
+//	r[style-name^='Hidden'] => !
+//
+// Selectors match paragraphs (p) or runs (r) by style ID (.ID) or by style
+// name (case-insensitive; ^= matches a prefix). The first matching rule wins
+// and takes precedence over built-in heading detection. Consecutive
+// paragraphs mapped to a nested path or to pre are collapsed into one block
+// unless the innermost element is marked :fresh. "!" drops the content.
+// Blank lines, '#' comments and invalid rules are ignored.
 func WithStyleMap(styleMap string) Option {
 	return func(m *MarkItDown) {
 		m.styleMap = styleMap
 	}
 }
+
+// TrackedChangesMode selects how DOCX tracked changes (revisions) are rendered.
+type TrackedChangesMode int
+
+const (
+	// TrackedChangesAccept renders the document as if every change had been
+	// accepted: insertions are kept and deletions dropped. This is the default.
+	TrackedChangesAccept TrackedChangesMode = iota
+	// TrackedChangesReject renders the document as if every change had been
+	// rejected: deletions are kept and insertions dropped.
+	TrackedChangesReject
+	// TrackedChangesInline shows both, using CriticMarkup: {++inserted++} and
+	// {--deleted--}, each followed by a {>>author, date<<} comment.
+	TrackedChangesInline
+)
+
+// WithTrackedChanges sets how DOCX tracked changes are rendered (default:
+// TrackedChangesAccept). Moved text is treated as a deletion at its old
+// location and an insertion at its new one.
+func WithTrackedChanges(mode TrackedChangesMode) Option {
+	return func(m *MarkItDown) {
+		m.trackedChanges = mode
+	}
+}
+
+// PDFLayout selects how the reading order of PDF text is determined.
+type PDFLayout int
+
+const (
+	// PDFLayoutSingleColumn reads each page top to bottom, joining text at
+	// the same height into one line. This is the default.
+	PDFLayoutSingleColumn PDFLayout = iota
+	// PDFLayoutColumns detects column gutters with a recursive XY-cut and
+	// reads multi-column regions, such as those of papers and newsletters,
+	// column by column. Regions without a gutter are read as a single
+	// column, as are narrow columns like those of tables.
+	PDFLayoutColumns
+)
+
+// WithPDFLayout sets how the reading order of PDF pages is determined
+// (default: PDFLayoutSingleColumn).
+func WithPDFLayout(layout PDFLayout) Option {
+	return func(m *MarkItDown) {
+		m.pdfLayout = layout
+	}
+}
+
+// WithPDFTableOfContents starts the Markdown of PDFs that have a document
+// outline (bookmarks) with a "Contents" section listing it, with page
+// numbers (default: false). The outline is used for heading levels either
+// way.
+func WithPDFTableOfContents(include bool) Option {
+	return func(m *MarkItDown) {
+		m.pdfTOC = include
+	}
+}
+
+// WithPDFPages converts only the given PDF pages, for previews and
+// sampling (default: all). pages is a comma-separated list of 1-based page
+// numbers and ranges, such as "1-5,10" or "3-" for page 3 onwards; pages
+// past the end of a document are ignored. An invalid list fails the
+// conversion. WithMaxPages applies to the number of pages selected.
+func WithPDFPages(pages string) Option {
+	return func(m *MarkItDown) {
+		m.pdfPages = pages
+	}
+}
+
+// WithPDFPageMarkers precedes the text of each PDF page with a
+// <!-- Page N --> comment (default: false). Pages are also available as
+// result segments either way.
+func WithPDFPageMarkers(enable bool) Option {
+	return func(m *MarkItDown) {
+		m.pdfPageMarkers = enable
+	}
+}
+
+// WithPDFWorkers sets how many PDF engine instances may run at once
+// (default: DefaultPDFWorkers, shared by every MarkItDown without this
+// option). Each instance converts one PDF at a time and takes tens of
+// megabytes of memory; instances are started as needed and kept until Close,
+// or until the MarkItDown is garbage collected. Values below 1 are treated
+// as 1.
+func WithPDFWorkers(n int) Option {
+	return func(m *MarkItDown) {
+		m.pdfWorkers = max(n, 1)
+	}
+}
+
+// WithPDFAcquireTimeout sets how long a PDF conversion waits for a free
+// engine instance before failing (default: DefaultPDFAcquireTimeout). Zero
+// or less waits until the conversion's context is done.
+func WithPDFAcquireTimeout(d time.Duration) Option {
+	return func(m *MarkItDown) {
+		m.pdfAcquireTimeout = d
+	}
+}
+
+// WithPassword sets the password that opens encrypted documents (PDF) when
+// neither StreamInfo.Password nor ContextWithPassword supplies one
+// (default: none).
+func WithPassword(password string) Option {
+	return func(m *MarkItDown) {
+		m.password = password
+	}
+}
+
+// WithHeadersFooters includes DOCX page headers and footers in the output
+// (default: false). Each section's headers precede its content and its
+// footers follow it, separated by a horizontal rule; a header or footer
+// identical to one already emitted is skipped. For PDFs, it keeps the
+// running headers, footers and page numbers that are otherwise removed:
+// lines repeated at the same height near the top or bottom of at least half
+// of the pages.
+func WithHeadersFooters(include bool) Option {
+	return func(m *MarkItDown) {
+		m.headersFooters = include
+	}
+}
+
+// WithAssetWriter stores embedded images from DOCX, PPTX, EPUB and XLSX
+// documents, and data URI images in HTML, with w and links them from the
+// Markdown instead of inlining them (default: none). Images with identical
+// content are stored once per conversion.
+func WithAssetWriter(w AssetWriter) Option {
+	return func(m *MarkItDown) {
+		m.assetWriter = w
+	}
+}
+
+// WithImageDescriber generates alt text with d for images in DOCX, PPTX,
+// EPUB and HTML documents whose alt text is missing or just a file name
+// (default: none). Failed descriptions are reported as result warnings.
+func WithImageDescriber(d ImageDescriber) Option {
+	return func(m *MarkItDown) {
+		m.imageDescriber = d
+	}
+}
+
+// WithOCR recognizes text with e in PDF pages that have no text layer, such
+// as scanned pages, and in PNG, JPEG and TIFF images, which are otherwise
+// unsupported (default: none). PDF pages that fail OCR are skipped and
+// reported as result warnings.
+func WithOCR(e OCREngine) Option {
+	return func(m *MarkItDown) {
+		m.ocr = e
+	}
+}
+
+// WithFrontMatter prepends the document metadata (title, author, dates,
+// keywords, custom properties, ...) to the Markdown as a YAML front matter
+// block (default: false). Documents without metadata are left unchanged.
+func WithFrontMatter(enable bool) Option {
+	return func(m *MarkItDown) {
+		m.frontMatter = enable
+	}
+}
+
+// WithMaxInputBytes limits the size of the input document, including
+// documents fetched by ConvertURL and entries extracted from archives
+// (default: unlimited). Zero or less disables the limit.
+func WithMaxInputBytes(n int64) Option {
+	return func(m *MarkItDown) {
+		m.limits.maxInputBytes = n
+	}
+}
+
+// WithMaxDecompressedBytes limits the total uncompressed size of the ZIP
+// containers (ZIP, DOCX, PPTX, XLSX, EPUB) opened during one conversion,
+// nested archives included (default: DefaultMaxDecompressedBytes). Zero or
+// less disables the limit.
+func WithMaxDecompressedBytes(n int64) Option {
+	return func(m *MarkItDown) {
+		m.limits.maxDecompressedBytes = n
+	}
+}
+
+// WithMaxArchiveEntries limits the number of entries in a single ZIP
+// container (default: DefaultMaxArchiveEntries). Zero or less disables the
+// limit.
+func WithMaxArchiveEntries(n int) Option {
+	return func(m *MarkItDown) {
+		m.limits.maxArchiveEntries = n
+	}
+}
+
+// WithMaxNestingDepth limits how deeply ZIP archives may be nested inside
+// each other; the outermost archive is depth 1 (default:
+// DefaultMaxNestingDepth). Zero or less disables the limit.
+func WithMaxNestingDepth(n int) Option {
+	return func(m *MarkItDown) {
+		m.limits.maxNestingDepth = n
+	}
+}
+
+// WithMaxPages limits the number of pages in a PDF (default: unlimited).
+// Zero or less disables the limit.
+func WithMaxPages(n int) Option {
+	return func(m *MarkItDown) {
+		m.limits.maxPages = n
+	}
+}
+
+// WithHTTPClient sets the client ConvertURL uses to fetch documents
+// (default: a client with a DefaultHTTPTimeout timeout). Its transport,
+// timeout and redirect policy are honoured; the host policy is applied on
+// top of them. Unless the policy sets AllowPrivate, the proxy of an
+// *http.Transport is not used; see HostPolicy.
+func WithHTTPClient(client *http.Client) Option {
+	return func(m *MarkItDown) {
+		m.httpClient = client
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent by ConvertURL (default:
+// DefaultUserAgent).
+func WithUserAgent(userAgent string) Option {
+	return func(m *MarkItDown) {
+		m.userAgent = userAgent
+	}
+}
+
+// WithMaxResponseBytes limits the size of documents fetched by ConvertURL
+// (default: DefaultMaxResponseBytes). Zero or less disables the limit; the
+// WithMaxInputBytes limit still applies.
+func WithMaxResponseBytes(n int64) Option {
+	return func(m *MarkItDown) {
+		m.maxResponseBytes = n
+	}
+}
+
+// WithHostPolicy restricts the hosts ConvertURL may fetch from. By default
+// only public addresses are allowed, and requests bypass HTTP proxies so
+// that the addresses can be checked; see HostPolicy.
+func WithHostPolicy(policy HostPolicy) Option {
+	return func(m *MarkItDown) {
+		m.hostPolicy = policy
+	}
+}
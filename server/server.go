@@ -0,0 +1,329 @@
+// Copyright 2026 Conductor OSS
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+
+// Package server exposes MarkItDown conversion over HTTP.
+//
+// Endpoints:
+//
+//	POST /convert   convert a multipart upload (field "file") or a raw body
+//	GET  /healthz   liveness probe
+//	GET  /readyz    readiness probe (503 while draining)
+//
+// For raw bodies the Content-Type header and the filename, extension,
+// charset and mime_type query parameters are mapped into StreamInfo. The
+// password of an encrypted document is taken from the X-Document-Password
+// header or, in multipart uploads, a "password" field before the file. The
+// response is markdown unless the client asks for JSON via
+// "Accept: application/json" or "?format=json".
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	markitdown "github.com/conductor-oss/markitdown"
+	"github.com/gabriel-vasile/mimetype"
+)
+
+// Config controls request limits. Zero values select the defaults.
+type Config struct {
+	// MaxRequestBytes caps the request body size (default 32 MiB).
+	MaxRequestBytes int64
+	// Timeout bounds each conversion (default 60s).
+	Timeout time.Duration
+	// MaxConcurrent caps simultaneous conversions; requests beyond the cap
+	// are rejected with 503 (default runtime.NumCPU()).
+	MaxConcurrent int
+}
+
+const (
+	defaultMaxRequestBytes = 32 << 20
+	defaultTimeout         = 60 * time.Second
+
+	// statusClientClosedRequest is nginx's non-standard status for requests
+	// whose client disconnected before the response, which is not a server
+	// error.
+	statusClientClosedRequest = 499
+)
+
+// Server is an http.Handler serving the conversion API.
+type Server struct {
+	markitdown *markitdown.MarkItDown
+	config     Config
+	sem        chan struct{}
+	ready      atomic.Bool
+	mux        *http.ServeMux
+}
+
+// New creates a Server that converts with m.
+func New(m *markitdown.MarkItDown, config Config) *Server {
+	if config.MaxRequestBytes <= 0 {
+		config.MaxRequestBytes = defaultMaxRequestBytes
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaultTimeout
+	}
+	if config.MaxConcurrent <= 0 {
+		config.MaxConcurrent = runtime.NumCPU()
+	}
+
+	s := &Server{
+		markitdown: m,
+		config:     config,
+		sem:        make(chan struct{}, config.MaxConcurrent),
+		mux:        http.NewServeMux(),
+	}
+	s.ready.Store(true)
+
+	s.mux.HandleFunc("POST /convert", s.handleConvert)
+	s.mux.HandleFunc("GET /healthz", s.handleHealth)
+	s.mux.HandleFunc("GET /readyz", s.handleReady)
+	return s
+}
+
+// SetReady controls the /readyz response. Set it to false before shutting
+// down so load balancers stop routing new requests.
+func (s *Server) SetReady(ready bool) {
+	s.ready.Store(ready)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = io.WriteString(w, "ok\n")
+}
+
+func (s *Server) handleReady(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if !s.ready.Load() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = io.WriteString(w, "draining\n")
+		return
+	}
+	_, _ = io.WriteString(w, "ready\n")
+}
+
+// convertResponse is the JSON response body of /convert.
+type convertResponse struct {
+	Title    string            `json:"title,omitempty"`
+	Markdown string            `json:"markdown"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+	Warnings []string          `json:"warnings,omitempty"`
+}
+
+// errorResponse is the JSON body of an error response.
+type errorResponse struct {
+	Error string `json:"error"`
+	// PasswordRequired is set when the document is encrypted and the
+	// password is missing or, with Incorrect, wrong.
+	PasswordRequired bool `json:"password_required,omitempty"`
+	Incorrect        bool `json:"incorrect,omitempty"`
+}
+
+func (s *Server) handleConvert(w http.ResponseWriter, r *http.Request) {
+	select {
+	case s.sem <- struct{}{}:
+		defer func() { <-s.sem }()
+	default:
+		w.Header().Set("Retry-After", "1")
+		writeError(w, r, http.StatusServiceUnavailable, errors.New("too many concurrent conversions"))
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, s.config.MaxRequestBytes)
+	data, info, err := readDocument(r)
+	if err != nil {
+		status := http.StatusBadRequest
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		writeError(w, r, status, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), s.config.Timeout)
+	defer cancel()
+
+	result, err := s.markitdown.ConvertReaderContext(ctx, bytes.NewReader(data), info)
+	if err != nil {
+		writeError(w, r, statusForError(err), err)
+		return
+	}
+
+	if wantsJSON(r) {
+		writeJSON(w, http.StatusOK, convertResponse{
+			Title:    result.Title,
+			Markdown: result.Markdown,
+			Metadata: result.Metadata,
+			Warnings: result.Warnings,
+		})
+		return
+	}
+	w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+	_, _ = io.WriteString(w, result.Markdown+"\n")
+}
+
+// readDocument reads the uploaded document and builds its StreamInfo from
+// the multipart part or request headers and query parameters.
+func readDocument(r *http.Request) ([]byte, markitdown.StreamInfo, error) {
+	q := r.URL.Query()
+	info := markitdown.StreamInfo{
+		Filename:  q.Get("filename"),
+		Extension: q.Get("extension"),
+		Charset:   q.Get("charset"),
+		MIMEType:  q.Get("mime_type"),
+		Password:  r.Header.Get("X-Document-Password"),
+	}
+
+	var data []byte
+	mediaType, params, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if strings.HasPrefix(mediaType, "multipart/") {
+		mr, err := r.MultipartReader()
+		if err != nil {
+			return nil, info, fmt.Errorf("read multipart body: %w", err)
+		}
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				return nil, info, errors.New(`multipart body has no "file" part`)
+			}
+			if err != nil {
+				return nil, info, fmt.Errorf("read multipart body: %w", err)
+			}
+			if part.FormName() == "password" {
+				pw, err := io.ReadAll(io.LimitReader(part, 1<<10))
+				part.Close()
+				if err != nil {
+					return nil, info, fmt.Errorf("read multipart body: %w", err)
+				}
+				info.Password = string(pw)
+				continue
+			}
+			if part.FormName() != "file" {
+				part.Close()
+				continue
+			}
+			data, err = io.ReadAll(part)
+			part.Close()
+			if err != nil {
+				return nil, info, fmt.Errorf("read upload: %w", err)
+			}
+			if info.Filename == "" {
+				info.Filename = part.FileName()
+			}
+			if info.MIMEType == "" {
+				partType, partParams, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+				if partType != "application/octet-stream" {
+					info.MIMEType = partType
+				}
+				if info.Charset == "" {
+					info.Charset = partParams["charset"]
+				}
+			}
+			break
+		}
+	} else {
+		var err error
+		data, err = io.ReadAll(r.Body)
+		if err != nil {
+			return nil, info, fmt.Errorf("read body: %w", err)
+		}
+		if info.MIMEType == "" && mediaType != "application/octet-stream" {
+			info.MIMEType = mediaType
+		}
+		if info.Charset == "" {
+			info.Charset = params["charset"]
+		}
+		if info.Filename == "" {
+			if _, cd, err := mime.ParseMediaType(r.Header.Get("Content-Disposition")); err == nil {
+				info.Filename = cd["filename"]
+			}
+		}
+	}
+
+	info.Filename = filepath.Base(info.Filename)
+	if info.Filename == "." || info.Filename == string(filepath.Separator) {
+		info.Filename = ""
+	}
+	if info.Extension == "" {
+		info.Extension = filepath.Ext(info.Filename)
+	}
+	info.Extension = strings.ToLower(info.Extension)
+	if info.Extension != "" && !strings.HasPrefix(info.Extension, ".") {
+		info.Extension = "." + info.Extension
+	}
+	if info.MIMEType == "" {
+		if mt := mimetype.Detect(data); mt.String() != "application/octet-stream" {
+			info.MIMEType, _, _ = mime.ParseMediaType(mt.String())
+		}
+	}
+	return data, info, nil
+}
+
+// statusForError maps conversion errors to HTTP status codes.
+func statusForError(err error) int {
+	var convErr *markitdown.ConversionError
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, context.Canceled):
+		return statusClientClosedRequest
+	case markitdown.IsUnsupportedFormat(err):
+		return http.StatusUnsupportedMediaType
+	case markitdown.IsLimitExceeded(err):
+		return http.StatusRequestEntityTooLarge
+	case markitdown.IsPasswordRequired(err), errors.As(err, &convErr):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func wantsJSON(r *http.Request) bool {
+	if r.URL.Query().Get("format") == "json" {
+		return true
+	}
+	return strings.Contains(r.Header.Get("Accept"), "application/json")
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, status int, err error) {
+	if wantsJSON(r) {
+		resp := errorResponse{Error: err.Error()}
+		var pwErr *markitdown.PasswordRequiredError
+		if errors.As(err, &pwErr) {
+			resp.PasswordRequired, resp.Incorrect = true, pwErr.Incorrect
+		}
+		writeJSON(w, status, resp)
+		return
+	}
+	http.Error(w, err.Error(), status)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
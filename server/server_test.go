@@ -0,0 +1,161 @@
+// Copyright 2026 Conductor OSS
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	markitdown "github.com/conductor-oss/markitdown"
+)
+
+func TestConvert(t *testing.T) {
+	ts := httptest.NewServer(New(markitdown.New(), Config{MaxRequestBytes: 1 << 20}))
+	defer ts.Close()
+
+	t.Run("raw body", func(t *testing.T) {
+		resp, err := http.Post(ts.URL+"/convert", "text/csv", strings.NewReader("a,b\n1,2\n"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("status = %d, body = %s", resp.StatusCode, body)
+		}
+		if !strings.Contains(string(body), "| a | b |") {
+			t.Errorf("unexpected markdown: %s", body)
+		}
+	})
+
+	t.Run("multipart json", func(t *testing.T) {
+		data, err := os.ReadFile("../testdata/test.pptx")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		mw := multipart.NewWriter(&buf)
+		fw, _ := mw.CreateFormFile("file", "test.pptx")
+		_, _ = fw.Write(data)
+		_ = mw.Close()
+
+		req, _ := http.NewRequest(http.MethodPost, ts.URL+"/convert", &buf)
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+		req.Header.Set("Accept", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("status = %d", resp.StatusCode)
+		}
+		var out convertResponse
+		if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(out.Markdown, "<!-- Slide number: 1 -->") {
+			t.Errorf("unexpected markdown: %.200s", out.Markdown)
+		}
+		if out.Metadata[markitdown.MetadataAuthor] != "Adam Fourney" {
+			t.Errorf("metadata = %v", out.Metadata)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			body   []byte
+			ctype  string
+			status int
+		}{
+			{"too large", bytes.Repeat([]byte("x"), 2<<20), "text/plain", http.StatusRequestEntityTooLarge},
+			{"unsupported", []byte{0x00, 0x01, 0x02}, "application/octet-stream", http.StatusUnsupportedMediaType},
+		}
+		for _, tt := range tests {
+			resp, err := http.Post(ts.URL+"/convert", tt.ctype, bytes.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.status {
+				t.Errorf("%s: status = %d, want %d", tt.name, resp.StatusCode, tt.status)
+			}
+		}
+	})
+}
+
+func TestStatusForError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{fmt.Errorf("convert: %w", context.DeadlineExceeded), http.StatusGatewayTimeout},
+		{fmt.Errorf("convert: %w", context.Canceled), statusClientClosedRequest},
+		{fmt.Errorf("convert: %w", &markitdown.PasswordRequiredError{Incorrect: true}), http.StatusUnprocessableEntity},
+		{errors.New("boom"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		if got := statusForError(tt.err); got != tt.want {
+			t.Errorf("statusForError(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestWriteErrorPassword(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/convert?format=json", nil)
+	err := fmt.Errorf("convert: %w", &markitdown.PasswordRequiredError{Incorrect: true})
+	writeError(rec, req, statusForError(err), err)
+
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusUnprocessableEntity)
+	}
+	var body errorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if !body.PasswordRequired || !body.Incorrect {
+		t.Errorf("body = %+v, want password_required and incorrect set", body)
+	}
+}
+
+func TestProbes(t *testing.T) {
+	s := New(markitdown.New(), Config{})
+	for _, tt := range []struct {
+		path   string
+		ready  bool
+		status int
+	}{
+		{"/healthz", true, http.StatusOK},
+		{"/readyz", true, http.StatusOK},
+		{"/readyz", false, http.StatusServiceUnavailable},
+		{"/healthz", false, http.StatusOK},
+	} {
+		s.SetReady(tt.ready)
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		if rec.Code != tt.status {
+			t.Errorf("GET %s (ready=%v) = %d, want %d", tt.path, tt.ready, rec.Code, tt.status)
+		}
+	}
+}
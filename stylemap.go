@@ -0,0 +1,190 @@
+// Copyright 2026 Conductor OSS
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+
+package markitdown
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// styleMapping is a single rule of a mammoth-style DOCX style map, e.g.
+//
+//	p[style-name='Code Block'] => pre
+//	r[style-name='Emphasis'] => em
+//	p.Callout => blockquote > p:fresh
+type styleMapping struct {
+	element    string // "p" (paragraph) or "r" (run)
+	styleID    string // matched exactly when set
+	styleName  string // matched case-insensitively when set
+	namePrefix bool   // styleName is a prefix (^=) rather than an exact match
+	target     []styleTarget
+	ignore     bool // "!" target: drop the content entirely
+}
+
+// styleTarget is one element of a mapping's output path.
+type styleTarget struct {
+	tag   string
+	fresh bool
+}
+
+// styleMap is an ordered list of mappings; the first match wins.
+type styleMap []styleMapping
+
+var (
+	reStyleSelector = regexp.MustCompile(`^(p|r)(?:\.([A-Za-z0-9_-]+))?(?:\[style-name(\^?=)(?:'([^']*)'|"([^"]*)")\])?$`)
+	reStyleTarget   = regexp.MustCompile(`^([a-z][a-z0-9]*)(:fresh)?$`)
+)
+
+// parseStyleMap parses a newline-separated style map. Blank lines and lines
+// starting with '#' are ignored. Invalid lines are skipped and reported.
+func parseStyleMap(s string) (styleMap, []string) {
+	var sm styleMap
+	var invalid []string
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		mapping, err := parseStyleMapping(line)
+		if err != nil {
+			invalid = append(invalid, fmt.Sprintf("style map: %q: %v", line, err))
+			continue
+		}
+		sm = append(sm, mapping)
+	}
+	return sm, invalid
+}
+
+func parseStyleMapping(line string) (styleMapping, error) {
+	selector, target, ok := strings.Cut(line, "=>")
+	if !ok {
+		return styleMapping{}, fmt.Errorf("missing =>")
+	}
+
+	m := reStyleSelector.FindStringSubmatch(strings.TrimSpace(selector))
+	if m == nil {
+		return styleMapping{}, fmt.Errorf("invalid selector")
+	}
+	mapping := styleMapping{
+		element:    m[1],
+		styleID:    m[2],
+		styleName:  m[4] + m[5],
+		namePrefix: m[3] == "^=",
+	}
+
+	target = strings.TrimSpace(target)
+	if target == "!" {
+		mapping.ignore = true
+		return mapping, nil
+	}
+	if target == "" {
+		return mapping, nil
+	}
+	for _, part := range strings.Split(target, ">") {
+		tm := reStyleTarget.FindStringSubmatch(strings.TrimSpace(part))
+		if tm == nil {
+			return styleMapping{}, fmt.Errorf("invalid target %q", strings.TrimSpace(part))
+		}
+		mapping.target = append(mapping.target, styleTarget{tag: tm[1], fresh: tm[2] != ""})
+	}
+	return mapping, nil
+}
+
+// lookup returns the first mapping for element ("p" or "r") that matches the
+// given style, or nil.
+func (sm styleMap) lookup(element, styleID, styleName string) *styleMapping {
+	if styleID == "" && styleName == "" {
+		return nil
+	}
+	for i := range sm {
+		mapping := &sm[i]
+		if mapping.element != element {
+			continue
+		}
+		if mapping.styleID != "" && mapping.styleID != styleID {
+			continue
+		}
+		if mapping.styleName != "" {
+			name := strings.ToLower(styleName)
+			want := strings.ToLower(mapping.styleName)
+			if mapping.namePrefix && !strings.HasPrefix(name, want) {
+				continue
+			}
+			if !mapping.namePrefix && name != want {
+				continue
+			}
+		}
+		return mapping
+	}
+	return nil
+}
+
+// wrapInline wraps run text in the mapping's target elements.
+func (mapping *styleMapping) wrapInline(text string) string {
+	if mapping.ignore {
+		return ""
+	}
+	for i := len(mapping.target) - 1; i >= 0; i-- {
+		tag := mapping.target[i].tag
+		text = "<" + tag + ">" + text + "</" + tag + ">"
+	}
+	return text
+}
+
+// canMerge reports whether a paragraph with this mapping may be collapsed into
+// the block started by a preceding paragraph with the same mapping. As in
+// mammoth, ":fresh" on the innermost element forces a new block.
+func (mapping *styleMapping) canMerge() bool {
+	if mapping.ignore || len(mapping.target) == 0 {
+		return false
+	}
+	if mapping.target[len(mapping.target)-1].fresh {
+		return false
+	}
+	// A single non-pre element (h1, p, ...) has nothing to collapse into.
+	return len(mapping.target) > 1 || mapping.target[0].tag == "pre"
+}
+
+// wrapBlock renders consecutive paragraphs sharing this mapping. Outer
+// elements wrap the whole group; the innermost element wraps each paragraph,
+// except pre, which joins its paragraphs with newlines.
+func (mapping *styleMapping) wrapBlock(paras []string) string {
+	if mapping.ignore {
+		return ""
+	}
+	if len(mapping.target) == 0 {
+		return "<p>" + strings.Join(paras, "</p><p>") + "</p>"
+	}
+
+	inner := mapping.target[len(mapping.target)-1].tag
+	var body string
+	if inner == "pre" {
+		lines := make([]string, len(paras))
+		for i, p := range paras {
+			lines[i] = strings.ReplaceAll(p, "<br/>", "\n")
+		}
+		body = "<pre><code>" + strings.Join(lines, "\n") + "</code></pre>"
+	} else {
+		var b strings.Builder
+		for _, p := range paras {
+			b.WriteString("<" + inner + ">" + p + "</" + inner + ">")
+		}
+		body = b.String()
+	}
+
+	for i := len(mapping.target) - 2; i >= 0; i-- {
+		tag := mapping.target[i].tag
+		body = "<" + tag + ">" + body + "</" + tag + ">"
+	}
+	return body
+}
@@ -0,0 +1,443 @@
+// Copyright 2026 Conductor OSS
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+
+// Package worker runs MarkItDown as a Conductor task worker.
+//
+// The worker polls a Conductor server for tasks of type "markitdown_convert"
+// (configurable). Task input:
+//
+//	url             document URL to fetch and convert, or
+//	content_base64  document bytes, with optional hints:
+//	filename, extension, mime_type, charset
+//	password        password of an encrypted document (PDF)
+//
+// On success the task completes with output markdown, title, metadata and
+// warnings. Failures set error_type in the output: invalid input (including
+// URLs rejected by the host policy), unsupported formats, exceeded resource
+// limits and missing or wrong passwords fail terminally (retrying cannot
+// help); fetch and
+// conversion errors fail with FAILED so Conductor applies the task's retry
+// policy.
+package worker
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	markitdown "github.com/conductor-oss/markitdown"
+)
+
+// DefaultTaskType is the Conductor task type polled by default.
+const DefaultTaskType = "markitdown_convert"
+
+// Task statuses reported to Conductor.
+const (
+	StatusCompleted               = "COMPLETED"
+	StatusFailed                  = "FAILED"
+	StatusFailedWithTerminalError = "FAILED_WITH_TERMINAL_ERROR"
+)
+
+// Values of the error_type output key.
+const (
+	ErrorInvalidInput      = "invalid_input"
+	ErrorUnsupportedFormat = "unsupported_format"
+	ErrorLimitExceeded     = "limit_exceeded"
+	ErrorPasswordRequired  = "password_required"
+	ErrorConversionFailed  = "conversion_failed"
+	ErrorFetchFailed       = "fetch_failed"
+)
+
+// Config configures a Worker. Zero values select the defaults.
+type Config struct {
+	// ServerURL is the Conductor API base URL, e.g. "http://localhost:8080/api".
+	ServerURL string
+	// TaskType is the task type to poll (default DefaultTaskType).
+	TaskType string
+	// WorkerID identifies this worker to Conductor (default: hostname).
+	WorkerID string
+	// Domain is the optional task domain.
+	Domain string
+	// Concurrency is the number of tasks processed in parallel (default runtime.NumCPU()).
+	Concurrency int
+	// PollInterval is the wait between polls that return no task (default 1s).
+	PollInterval time.Duration
+	// TaskTimeout bounds each conversion (default 5m).
+	TaskTimeout time.Duration
+	// UpdateRetries is how many times a failed task update is retried (default 3).
+	UpdateRetries int
+	// AuthKey and AuthSecret are exchanged for an access token when set.
+	AuthKey    string
+	AuthSecret string
+	// HTTPClient is used for Conductor API calls (default http.DefaultClient).
+	HTTPClient *http.Client
+	// Logger receives operational messages (default: standard logger).
+	Logger *log.Logger
+}
+
+// Worker polls Conductor and converts documents.
+type Worker struct {
+	markitdown *markitdown.MarkItDown
+	config     Config
+
+	tokenMu sync.Mutex
+	token   string
+}
+
+// New creates a Worker that converts with m.
+func New(m *markitdown.MarkItDown, config Config) *Worker {
+	config.ServerURL = strings.TrimRight(config.ServerURL, "/")
+	if config.TaskType == "" {
+		config.TaskType = DefaultTaskType
+	}
+	if config.WorkerID == "" {
+		config.WorkerID, _ = os.Hostname()
+	}
+	if config.Concurrency <= 0 {
+		config.Concurrency = runtime.NumCPU()
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = time.Second
+	}
+	if config.TaskTimeout <= 0 {
+		config.TaskTimeout = 5 * time.Minute
+	}
+	if config.UpdateRetries <= 0 {
+		config.UpdateRetries = 3
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = http.DefaultClient
+	}
+	if config.Logger == nil {
+		config.Logger = log.Default()
+	}
+	return &Worker{markitdown: m, config: config}
+}
+
+// Task is the subset of a Conductor task used by the worker.
+type Task struct {
+	TaskID             string         `json:"taskId"`
+	WorkflowInstanceID string         `json:"workflowInstanceId"`
+	TaskType           string         `json:"taskType"`
+	InputData          map[string]any `json:"inputData"`
+}
+
+// TaskResult is the update sent to Conductor when a task finishes.
+type TaskResult struct {
+	WorkflowInstanceID    string         `json:"workflowInstanceId"`
+	TaskID                string         `json:"taskId"`
+	WorkerID              string         `json:"workerId"`
+	Status                string         `json:"status"`
+	OutputData            map[string]any `json:"outputData,omitempty"`
+	ReasonForIncompletion string         `json:"reasonForIncompletion,omitempty"`
+}
+
+// Run polls for tasks until ctx is done, then waits for in-flight tasks to
+// finish and report their results before returning.
+func (w *Worker) Run(ctx context.Context) error {
+	if w.config.ServerURL == "" {
+		return errors.New("worker: ServerURL is required")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < w.config.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.pollLoop(ctx)
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+func (w *Worker) pollLoop(ctx context.Context) {
+	for ctx.Err() == nil {
+		task, err := w.poll(ctx)
+		if err != nil && ctx.Err() == nil {
+			w.config.Logger.Printf("worker: poll %s: %v", w.config.TaskType, err)
+		}
+		if task == nil {
+			select {
+			case <-ctx.Done():
+			case <-time.After(w.config.PollInterval):
+			}
+			continue
+		}
+
+		// Finish the task even if shutdown starts while it runs.
+		result := w.Execute(context.WithoutCancel(ctx), task)
+		if err := w.update(context.WithoutCancel(ctx), result); err != nil {
+			w.config.Logger.Printf("worker: update task %s: %v", task.TaskID, err)
+		}
+	}
+}
+
+// poll fetches one task, returning nil when none is available.
+func (w *Worker) poll(ctx context.Context) (*Task, error) {
+	q := url.Values{"workerid": {w.config.WorkerID}}
+	if w.config.Domain != "" {
+		q.Set("domain", w.config.Domain)
+	}
+	endpoint := w.config.ServerURL + "/tasks/poll/" + url.PathEscape(w.config.TaskType) + "?" + q.Encode()
+
+	resp, err := w.do(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNoContent || len(bytes.TrimSpace(body)) == 0 {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("poll: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var task Task
+	if err := json.Unmarshal(body, &task); err != nil {
+		return nil, fmt.Errorf("decode task: %w", err)
+	}
+	if task.TaskID == "" {
+		return nil, nil
+	}
+	return &task, nil
+}
+
+// update reports a task result, retrying transient failures with backoff.
+func (w *Worker) update(ctx context.Context, result TaskResult) error {
+	payload, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	backoff := 500 * time.Millisecond
+	for attempt := 0; ; attempt++ {
+		err = w.postResult(ctx, payload)
+		if err == nil || attempt >= w.config.UpdateRetries {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (w *Worker) postResult(ctx context.Context, payload []byte) error {
+	resp, err := w.do(ctx, http.MethodPost, w.config.ServerURL+"/tasks", payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// do sends an authenticated request to the Conductor API.
+func (w *Worker) do(ctx context.Context, method, endpoint string, body []byte) (*http.Response, error) {
+	token, err := w.accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	if token != "" {
+		req.Header.Set("X-Authorization", token)
+	}
+	resp, err := w.config.HTTPClient.Do(req)
+	if err == nil && resp.StatusCode == http.StatusUnauthorized && token != "" {
+		// Token expired; fetch a new one on the next call.
+		w.tokenMu.Lock()
+		w.token = ""
+		w.tokenMu.Unlock()
+	}
+	return resp, err
+}
+
+// accessToken returns a cached access token, exchanging the key and secret
+// for a new one when needed. It returns "" when no credentials are set.
+func (w *Worker) accessToken(ctx context.Context) (string, error) {
+	if w.config.AuthKey == "" {
+		return "", nil
+	}
+	w.tokenMu.Lock()
+	defer w.tokenMu.Unlock()
+	if w.token != "" {
+		return w.token, nil
+	}
+
+	payload, _ := json.Marshal(map[string]string{"keyId": w.config.AuthKey, "keySecret": w.config.AuthSecret})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.config.ServerURL+"/token", bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := w.config.HTTPClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("get token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("get token: %s", resp.Status)
+	}
+	var out struct {
+		Token string `json:"token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", fmt.Errorf("decode token: %w", err)
+	}
+	w.token = out.Token
+	return w.token, nil
+}
+
+// Execute converts the document described by a task's input and builds the
+// result to report. It never returns an error; failures are encoded in the
+// result status and output.
+func (w *Worker) Execute(ctx context.Context, task *Task) TaskResult {
+	result := TaskResult{
+		WorkflowInstanceID: task.WorkflowInstanceID,
+		TaskID:             task.TaskID,
+		WorkerID:           w.config.WorkerID,
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, w.config.TaskTimeout)
+	defer cancel()
+
+	doc, err := w.convert(ctx, task.InputData)
+	if err != nil {
+		errorType, status := classifyError(err)
+		result.Status = status
+		result.ReasonForIncompletion = err.Error()
+		result.OutputData = map[string]any{"error_type": errorType, "error": err.Error()}
+		return result
+	}
+
+	result.Status = StatusCompleted
+	result.OutputData = map[string]any{
+		"markdown": doc.Markdown,
+		"title":    doc.Title,
+		"metadata": doc.Metadata,
+		"warnings": doc.Warnings,
+	}
+	return result
+}
+
+// inputError marks a problem with the task input.
+type inputError struct{ msg string }
+
+func (e *inputError) Error() string { return e.msg }
+
+// fetchError marks a failure to retrieve the input URL.
+type fetchError struct{ err error }
+
+func (e *fetchError) Error() string { return e.err.Error() }
+func (e *fetchError) Unwrap() error { return e.err }
+
+func (w *Worker) convert(ctx context.Context, input map[string]any) (*markitdown.DocumentConverterResult, error) {
+	str := func(key string) string {
+		s, _ := input[key].(string)
+		return s
+	}
+
+	if password := str("password"); password != "" {
+		ctx = markitdown.ContextWithPassword(ctx, password)
+	}
+
+	src, b64 := str("url"), str("content_base64")
+	switch {
+	case src != "" && b64 != "":
+		return nil, &inputError{"provide either url or content_base64, not both"}
+
+	case src != "":
+		if !strings.HasPrefix(src, "http://") && !strings.HasPrefix(src, "https://") {
+			return nil, &inputError{fmt.Sprintf("url must be http or https: %q", src)}
+		}
+		result, err := w.markitdown.ConvertURLContext(ctx, src)
+		var convErr *markitdown.ConversionError
+		if err != nil && !markitdown.IsUnsupportedFormat(err) && !markitdown.IsLimitExceeded(err) &&
+			!markitdown.IsPasswordRequired(err) && !errors.As(err, &convErr) {
+			return nil, &fetchError{err}
+		}
+		return result, err
+
+	case b64 != "":
+		data, err := base64.StdEncoding.DecodeString(b64)
+		if err != nil {
+			return nil, &inputError{fmt.Sprintf("decode content_base64: %v", err)}
+		}
+		info := markitdown.StreamInfo{
+			Filename:  str("filename"),
+			Extension: strings.ToLower(str("extension")),
+			MIMEType:  str("mime_type"),
+			Charset:   str("charset"),
+		}
+		if info.Extension == "" {
+			info.Extension = strings.ToLower(filepath.Ext(info.Filename))
+		}
+		if info.Extension != "" && !strings.HasPrefix(info.Extension, ".") {
+			info.Extension = "." + info.Extension
+		}
+		return w.markitdown.ConvertReaderContext(ctx, bytes.NewReader(data), info)
+
+	default:
+		return nil, &inputError{"url or content_base64 is required"}
+	}
+}
+
+// classifyError maps a conversion error to an error_type and task status.
+func classifyError(err error) (string, string) {
+	var inErr *inputError
+	var fetchErr *fetchError
+	switch {
+	case errors.As(err, &inErr), errors.Is(err, markitdown.ErrHostNotAllowed):
+		return ErrorInvalidInput, StatusFailedWithTerminalError
+	case markitdown.IsUnsupportedFormat(err):
+		return ErrorUnsupportedFormat, StatusFailedWithTerminalError
+	case markitdown.IsLimitExceeded(err):
+		return ErrorLimitExceeded, StatusFailedWithTerminalError
+	case markitdown.IsPasswordRequired(err):
+		return ErrorPasswordRequired, StatusFailedWithTerminalError
+	case errors.As(err, &fetchErr):
+		return ErrorFetchFailed, StatusFailed
+	default:
+		return ErrorConversionFailed, StatusFailed
+	}
+}
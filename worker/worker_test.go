@@ -0,0 +1,196 @@
+// Copyright 2026 Conductor OSS
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	markitdown "github.com/conductor-oss/markitdown"
+)
+
+// fakeConductor is a minimal stand-in for the Conductor task API.
+type fakeConductor struct {
+	mu          sync.Mutex
+	queue       []Task
+	results     map[string]TaskResult
+	failUpdates int // number of updates to reject with 500 before accepting
+	done        chan struct{}
+	want        int
+}
+
+func (f *fakeConductor) handler(t *testing.T) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/token", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"token": "tok"})
+	})
+	mux.HandleFunc("GET /api/tasks/poll/{taskType}", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Authorization") != "tok" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.PathValue("taskType") != DefaultTaskType || r.URL.Query().Get("workerid") != "test-worker" {
+			t.Errorf("unexpected poll %s", r.URL)
+		}
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if len(f.queue) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		task := f.queue[0]
+		f.queue = f.queue[1:]
+		json.NewEncoder(w).Encode(task)
+	})
+	mux.HandleFunc("POST /api/tasks", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if f.failUpdates > 0 {
+			f.failUpdates--
+			http.Error(w, "unavailable", http.StatusInternalServerError)
+			return
+		}
+		var result TaskResult
+		if err := json.NewDecoder(r.Body).Decode(&result); err != nil {
+			t.Errorf("decode task result: %v", err)
+		}
+		f.results[result.TaskID] = result
+		if len(f.results) == f.want {
+			close(f.done)
+		}
+		io.WriteString(w, result.TaskID)
+	})
+	return mux
+}
+
+func TestWorker(t *testing.T) {
+	docs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/encrypted.pdf" {
+			http.ServeFile(w, r, "../testdata/test_encrypted.pdf")
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		io.WriteString(w, "<html><head><title>Remote</title></head><body><h1>Hello</h1></body></html>")
+	}))
+	defer docs.Close()
+
+	b64 := func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }
+	fake := &fakeConductor{
+		queue: []Task{
+			{TaskID: "csv", InputData: map[string]any{"content_base64": b64("a,b\n1,2\n"), "filename": "data.csv"}},
+			{TaskID: "url", InputData: map[string]any{"url": docs.URL + "/page.html"}},
+			{TaskID: "unsupported", InputData: map[string]any{"content_base64": b64("\x00\x01\x02"), "extension": "bin"}},
+			{TaskID: "invalid", InputData: map[string]any{}},
+			{TaskID: "password", InputData: map[string]any{"url": docs.URL + "/encrypted.pdf", "password": "secret"}},
+			{TaskID: "no-password", InputData: map[string]any{"url": docs.URL + "/encrypted.pdf"}},
+		},
+		results:     map[string]TaskResult{},
+		failUpdates: 1,
+		done:        make(chan struct{}),
+		want:        6,
+	}
+	conductor := httptest.NewServer(fake.handler(t))
+	defer conductor.Close()
+
+	m := markitdown.New(markitdown.WithHostPolicy(markitdown.HostPolicy{AllowPrivate: true}))
+	w := New(m, Config{
+		ServerURL:    conductor.URL + "/api",
+		WorkerID:     "test-worker",
+		Concurrency:  2,
+		PollInterval: 10 * time.Millisecond,
+		AuthKey:      "key",
+		AuthSecret:   "secret",
+		Logger:       log.New(io.Discard, "", 0),
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error, 1)
+	go func() { errc <- w.Run(ctx) }()
+
+	select {
+	case <-fake.done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for task results")
+	}
+	cancel()
+	select {
+	case err := <-errc:
+		if err != nil {
+			t.Fatalf("Run error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after cancel")
+	}
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+
+	tests := []struct {
+		taskID    string
+		status    string
+		errorType string
+		markdown  string
+	}{
+		{"csv", StatusCompleted, "", "| a | b |"},
+		{"url", StatusCompleted, "", "# Hello"},
+		{"unsupported", StatusFailedWithTerminalError, ErrorUnsupportedFormat, ""},
+		{"invalid", StatusFailedWithTerminalError, ErrorInvalidInput, ""},
+		{"password", StatusCompleted, "", "Confidential figures."},
+		{"no-password", StatusFailedWithTerminalError, ErrorPasswordRequired, ""},
+	}
+	for _, tt := range tests {
+		result, ok := fake.results[tt.taskID]
+		if !ok {
+			t.Errorf("%s: no result reported", tt.taskID)
+			continue
+		}
+		if result.Status != tt.status {
+			t.Errorf("%s: status = %s, want %s (%s)", tt.taskID, result.Status, tt.status, result.ReasonForIncompletion)
+		}
+		if result.WorkerID != "test-worker" {
+			t.Errorf("%s: workerId = %q", tt.taskID, result.WorkerID)
+		}
+		if got, _ := result.OutputData["error_type"].(string); got != tt.errorType {
+			t.Errorf("%s: error_type = %q, want %q", tt.taskID, got, tt.errorType)
+		}
+		if md, _ := result.OutputData["markdown"].(string); !strings.Contains(md, tt.markdown) {
+			t.Errorf("%s: markdown = %q, want it to contain %q", tt.taskID, md, tt.markdown)
+		}
+	}
+	if title, _ := fake.results["url"].OutputData["title"].(string); title != "Remote" {
+		t.Errorf("url: title = %q, want Remote", title)
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	convErr := &markitdown.ConversionError{Attempts: []markitdown.FailedConversionAttempt{{Converter: "pdf", Err: io.ErrUnexpectedEOF}}}
+	if errorType, status := classifyError(convErr); errorType != ErrorConversionFailed || status != StatusFailed {
+		t.Errorf("ConversionError -> %s/%s, want %s/%s", errorType, status, ErrorConversionFailed, StatusFailed)
+	}
+	fetchErr := &fetchError{io.ErrUnexpectedEOF}
+	if errorType, status := classifyError(fetchErr); errorType != ErrorFetchFailed || status != StatusFailed {
+		t.Errorf("fetchError -> %s/%s, want %s/%s", errorType, status, ErrorFetchFailed, StatusFailed)
+	}
+	pwErr := &markitdown.PasswordRequiredError{Incorrect: true}
+	if errorType, status := classifyError(pwErr); errorType != ErrorPasswordRequired || status != StatusFailedWithTerminalError {
+		t.Errorf("PasswordRequiredError -> %s/%s, want %s/%s", errorType, status, ErrorPasswordRequired, StatusFailedWithTerminalError)
+	}
+}